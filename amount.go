@@ -1,5 +1,220 @@
 package c7api
 
-func centsToDollars(amount int) (dollars float64) {
-	return float64(amount) / 100.0
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents of Currency. Commerce7 sends amounts as bare
+// numbers, usually integer cents but sometimes with a fraction (line taxes
+// especially), so Money decodes either and rounds to the nearest cent. It
+// encodes back to integer cents; resource structs that keep UnknownFields
+// send an unchanged fractional amount back exactly as it came.
+//
+// Currency is not part of the payload. It is blank on decoded values until
+// set, e.g. from WinerySettings.Currency with WithCurrency, and a blank
+// currency is compatible with any other (see SameCurrency).
+type Money struct {
+	Cents    int64
+	Currency string // ISO 4217 code, e.g. "USD"
+}
+
+func NewMoney(cents int64, currency string) Money {
+	return Money{Cents: cents, Currency: currency}
+}
+
+// ParseMoney reads a decimal amount such as "49.99", "-5", "-$5" or
+// "$1,250.00" into cents of currency.
+func ParseMoney(amount string, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	s = strings.ReplaceAll(s, ",", "")
+
+	// Accept the sign either side of the dollar sign, but only once.
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative, s = true, s[1:]
+	}
+	s = strings.TrimPrefix(s, "$")
+	if !negative && strings.HasPrefix(s, "-") {
+		negative, s = true, s[1:]
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" && !hasFrac || hasFrac && (len(frac) == 0 || len(frac) > 2) {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+	// ParseInt would take a sign on either part, so "1.+5" or "$-0.-5"
+	// would slip through.
+	if !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+	f, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+
+	cents := w*100 + f
+	if negative {
+		cents = -cents
+	}
+	return Money{Cents: cents, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithCurrency returns m in currency, keeping the amount.
+func (m Money) WithCurrency(currency string) Money {
+	m.Currency = currency
+	return m
+}
+
+func (m Money) IsZero() bool     { return m.Cents == 0 }
+func (m Money) IsNegative() bool { return m.Cents < 0 }
+
+// Dollars returns the amount in major units, e.g. 12.34. Use it for display
+// and reporting only; arithmetic should stay in cents.
+func (m Money) Dollars() float64 { return float64(m.Cents) / 100.0 }
+
+// Decimal formats the amount in major units with two places, e.g. "-12.34".
+func (m Money) Decimal() string {
+	cents := m.Cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// String formats m as its Decimal followed by the currency, if known, e.g.
+// "12.34 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// Add returns m + other. The result takes m's currency, or other's if m's
+// is blank. Currencies are not checked, since they come from data; use
+// AddChecked where the two amounts may differ.
+func (m Money) Add(other Money) Money {
+	return Money{Cents: m.Cents + other.Cents, Currency: m.commonCurrency(other)}
+}
+
+// Sub returns m - other, with the currency chosen as for Add.
+func (m Money) Sub(other Money) Money {
+	return Money{Cents: m.Cents - other.Cents, Currency: m.commonCurrency(other)}
+}
+
+// AddChecked is Add, returning an error rather than a sum when m and other
+// are in two different known currencies.
+func (m Money) AddChecked(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, fmt.Errorf("cannot add %s to %s: mixed currencies", other, m)
+	}
+	return m.Add(other), nil
+}
+
+// SubChecked is Sub, returning an error on mixed currencies like AddChecked.
+func (m Money) SubChecked(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, fmt.Errorf("cannot subtract %s from %s: mixed currencies", other, m)
+	}
+	return m.Sub(other), nil
+}
+
+// Mul returns m multiplied by n, such as a unit price by a quantity.
+func (m Money) Mul(n int) Money {
+	return Money{Cents: m.Cents * int64(n), Currency: m.Currency}
+}
+
+func (m Money) Neg() Money {
+	return Money{Cents: -m.Cents, Currency: m.Currency}
+}
+
+func (m Money) Abs() Money {
+	if m.Cents < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Cmp returns -1, 0 or 1 as m is less than, equal to or greater than other,
+// comparing cents only. Check SameCurrency first if that matters.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.Cents < other.Cents:
+		return -1
+	case m.Cents > other.Cents:
+		return 1
+	}
+	return 0
+}
+
+// SameCurrency reports whether m and other can be combined: their
+// currencies match, ignoring case, or at least one is blank.
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == "" || other.Currency == "" || strings.EqualFold(m.Currency, other.Currency)
+}
+
+func (m Money) commonCurrency(other Money) string {
+	if m.Currency == "" {
+		return other.Currency
+	}
+	return m.Currency
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(m.Cents, 10)), nil
+}
+
+// UnmarshalJSON accepts integer or fractional cents, either bare or quoted,
+// and null. The currency is left as it was.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		m.Cents = 0
+		return nil
+	}
+	if len(data) > 1 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(strings.TrimSpace(s))
+	}
+
+	if cents, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+		m.Cents = cents
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("invalid money amount: %s", data)
+	}
+	m.Cents = int64(math.Round(f))
+	return nil
 }
@@ -0,0 +1,121 @@
+package c7api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{`4500`, 4500, false},
+		{`-1500`, -1500, false},
+		{`4500.0`, 4500, false},
+		{`254.1`, 254, false},
+		{`254.5`, 255, false},
+		{`-254.5`, -255, false},
+		{`"1200"`, 1200, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tc := range tests {
+		m := Money{Cents: 99, Currency: "USD"}
+		err := json.Unmarshal([]byte(tc.json), &m)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tc.json, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && (m.Cents != tc.want || m.Currency != "USD") {
+			t.Errorf("Unmarshal(%s) = %+v, want %d cents keeping USD", tc.json, m, tc.want)
+		}
+	}
+}
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Price   Money  `json:"price"`
+		Compare *Money `json:"comparePrice"`
+	}{Price: NewMoney(4500, "USD")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `{"price":4500,"comparePrice":null}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"49.99", 4999, false},
+		{"50", 5000, false},
+		{"$1,250.5", 125050, false},
+		{"-5.25", -525, false},
+		{".75", 75, false},
+		{"12.", 0, true},
+		{"1.234", 0, true},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"-$5", -500, false},
+		{"$-0.50", -50, false},
+		{"--5", 0, true},
+		{"1.+5", 0, true},
+		{"1.-5", 0, true},
+		{"+5", 0, true},
+	}
+	for _, tc := range tests {
+		got, err := ParseMoney(tc.in, "USD")
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseMoney(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && got.Cents != tc.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tc.in, got.Cents, tc.want)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price := NewMoney(4500, "USD")
+	tax := Money{Cents: 327} // decoded, currency not yet known
+
+	total := price.Mul(2).Add(tax)
+	if total.Cents != 9327 || total.Currency != "USD" {
+		t.Errorf("total = %+v", total)
+	}
+	if got := total.Sub(NewMoney(10000, "usd")); got.Decimal() != "-6.73" || got.Abs().String() != "6.73 USD" {
+		t.Errorf("difference = %s / %s", got.Decimal(), got.Abs())
+	}
+	if price.Cmp(tax) != 1 || tax.Cmp(price) != -1 || price.Cmp(price) != 0 {
+		t.Error("Cmp ordered amounts wrongly")
+	}
+	if got := (Money{Cents: 5}).Decimal(); got != "0.05" {
+		t.Errorf("Decimal = %s, want 0.05", got)
+	}
+
+	// Mixed currencies are data, not a programming error, so they are
+	// reported rather than panicking.
+	aud := NewMoney(100, "AUD")
+	if price.SameCurrency(aud) || !price.SameCurrency(tax) {
+		t.Error("SameCurrency compared currencies wrongly")
+	}
+	if _, err := price.AddChecked(aud); err == nil {
+		t.Error("expected an error adding USD to AUD")
+	}
+	if _, err := price.SubChecked(aud); err == nil {
+		t.Error("expected an error subtracting AUD from USD")
+	}
+	if got, err := price.AddChecked(tax); err != nil || got.Cents != 4827 {
+		t.Errorf("AddChecked = %v, %v", got, err)
+	}
+	if got := price.Add(aud); got.Cents != 4600 {
+		t.Errorf("Add = %v", got)
+	}
+}
@@ -42,6 +42,18 @@ func RequestWithRetryAndReadV2Context(ctx context.Context, method string, url st
 	return requestWithRetryAndRead(ctx, method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, v2Headers(tenant))
 }
 
+// RequestWithRetryAndReadV2Meta is RequestWithRetryAndReadV2, also returning
+// the final status, headers, request id, attempt count and total latency.
+func RequestWithRetryAndReadV2Meta(method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]byte, *ResponseMeta, error) {
+	return requestWithRetryAndReadMeta(context.Background(), method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, v2Headers(tenant))
+}
+
+// RequestWithRetryAndReadV2MetaContext is RequestWithRetryAndReadV2Meta with a
+// caller-supplied context.
+func RequestWithRetryAndReadV2MetaContext(ctx context.Context, method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]byte, *ResponseMeta, error) {
+	return requestWithRetryAndReadMeta(ctx, method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, v2Headers(tenant))
+}
+
 // v2Headers are the extra headers the experimental v2 API requires on top of
 // the standard v1 set.
 func v2Headers(tenant string) map[string]string {
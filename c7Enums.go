@@ -30,6 +30,36 @@ const (
 	OrderChannelWeb     = "Web"
 )
 
+// Purchase Type
+const (
+	OrderPurchaseTypeOrder  = "Order"
+	OrderPurchaseTypeRefund = "Refund"
+)
+
+// Tender Type
+const (
+	TenderTypeCreditCard = "Credit Card"
+	TenderTypeCash       = "Cash"
+	TenderTypeCheck      = "Check"
+	TenderTypeGiftCard   = "Gift Card"
+	TenderTypeLoyalty    = "Loyalty"
+	TenderTypeOther      = "Other"
+)
+
+func IsValidTenderType(tenderType string) bool {
+	switch tenderType {
+	case TenderTypeCreditCard,
+		TenderTypeCash,
+		TenderTypeCheck,
+		TenderTypeGiftCard,
+		TenderTypeLoyalty,
+		TenderTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
 // Product Type
 const (
 	ProductTypeGeneralMerchandise = "General Merchandise"
@@ -97,6 +127,49 @@ const (
 	ClubWebStatusNotActive = "Not Available"
 )
 
+func IsValidClubAdminStatus(status string) bool {
+	switch status {
+	case ClubAdminStatusActive,
+		ClubAdminStatusNotActive:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidClubWebStatus(status string) bool {
+	switch status {
+	case ClubWebStatusActive,
+		ClubWebStatusNotActive:
+		return true
+	default:
+		return false
+	}
+}
+
+// Club Type
+const (
+	ClubTypeTraditional  = "Traditional"
+	ClubTypeSubscription = "Subscription"
+)
+
+func IsValidClubType(clubType string) bool {
+	switch clubType {
+	case ClubTypeTraditional,
+		ClubTypeSubscription:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetValidClubTypes() []string {
+	return []string{
+		ClubTypeTraditional,
+		ClubTypeSubscription,
+	}
+}
+
 // Club Membership Status
 const (
 	ClubMembershipStatusActive    = "Active"
@@ -105,12 +178,34 @@ const (
 	ClubMembershipStatusPlanning  = "Planning"
 )
 
+func IsValidClubMembershipStatus(status string) bool {
+	switch status {
+	case ClubMembershipStatusActive,
+		ClubMembershipStatusCancelled,
+		ClubMembershipStatusOnHold,
+		ClubMembershipStatusPlanning:
+		return true
+	default:
+		return false
+	}
+}
+
 // GiftCard Status
 const (
 	GiftCardStatusActive    = "Active"
 	GiftCardStatusCancelled = "Cancelled"
 )
 
+func IsValidGiftCardStatus(status string) bool {
+	switch status {
+	case GiftCardStatusActive,
+		GiftCardStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
 // GiftCard Type
 
 const (
@@ -118,6 +213,34 @@ const (
 	GiftCardTypePhysical = "Physical"
 )
 
+func IsValidGiftCardType(giftCardType string) bool {
+	switch giftCardType {
+	case GiftCardTypeVirtual,
+		GiftCardTypePhysical:
+		return true
+	default:
+		return false
+	}
+}
+
+// Loyalty Transaction Type
+const (
+	LoyaltyTransactionTypeEarn   = "Earn"
+	LoyaltyTransactionTypeRedeem = "Redeem"
+	LoyaltyTransactionTypeAdjust = "Adjustment"
+)
+
+func IsValidLoyaltyTransactionType(transactionType string) bool {
+	switch transactionType {
+	case LoyaltyTransactionTypeEarn,
+		LoyaltyTransactionTypeRedeem,
+		LoyaltyTransactionTypeAdjust:
+		return true
+	default:
+		return false
+	}
+}
+
 // Tag X Object Types
 const (
 	TagXObjectTypeCustomer = "customer"
@@ -216,6 +339,62 @@ func GetValidWebStatuses() []string {
 	}
 }
 
+// Inventory Transaction Type
+const (
+	InventoryTransactionTypeReceive  = "Receive"
+	InventoryTransactionTypeAdjust   = "Adjustment"
+	InventoryTransactionTypeTransfer = "Transfer"
+)
+
+func IsValidInventoryTransactionType(transactionType string) bool {
+	switch transactionType {
+	case InventoryTransactionTypeReceive,
+		InventoryTransactionTypeAdjust,
+		InventoryTransactionTypeTransfer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Inventory Transaction Reason
+const (
+	InventoryReasonReceived        = "Received"
+	InventoryReasonCountCorrection = "Count Correction"
+	InventoryReasonDamaged         = "Damaged"
+	InventoryReasonLost            = "Lost"
+	InventoryReasonSample          = "Sample"
+	InventoryReasonTransfer        = "Transfer"
+	InventoryReasonOther           = "Other"
+)
+
+func IsValidInventoryReason(reason string) bool {
+	switch reason {
+	case InventoryReasonReceived,
+		InventoryReasonCountCorrection,
+		InventoryReasonDamaged,
+		InventoryReasonLost,
+		InventoryReasonSample,
+		InventoryReasonTransfer,
+		InventoryReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetValidInventoryReasons() []string {
+	return []string{
+		InventoryReasonReceived,
+		InventoryReasonCountCorrection,
+		InventoryReasonDamaged,
+		InventoryReasonLost,
+		InventoryReasonSample,
+		InventoryReasonTransfer,
+		InventoryReasonOther,
+	}
+}
+
 // MetaDataConfig Object Types
 // allocation, club-membership, collection, customer, customer-address, order, product, reservation, experience
 const (
@@ -246,3 +425,11 @@ func IsValidMetaDataConfigObjectType(objType string) bool {
 		return false
 	}
 }
+
+// Outcome of a single item in a bulk operation: product updates, loyalty
+// grants and fulfillment imports all report one of these per item.
+const (
+	BulkStatusApplied = "Applied"
+	BulkStatusSkipped = "Skipped"
+	BulkStatusFailed  = "Failed"
+)
@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -34,6 +33,25 @@ func GetContext[T any](ctx context.Context, url string, queries map[string]strin
 	return &v, nil
 }
 
+// GetWithMeta is Get, also returning the response meta.
+func GetWithMeta[T any](url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*T, *ResponseMeta, error) {
+	return GetWithMetaContext[T](context.Background(), url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// GetWithMetaContext is GetWithMeta with a caller-supplied context.
+func GetWithMetaContext[T any](ctx context.Context, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*T, *ResponseMeta, error) {
+	data, meta, err := RequestWithRetryAndReadMetaContext(ctx, http.MethodGet, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, meta, err
+	}
+	var v T
+	err = json.Unmarshal(*data, &v)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &v, meta, nil
+}
+
 func Post[T any](object *T, url string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]byte, error) {
 	return PostContext(context.Background(), object, url, tenant, c7AppAuthEncoded, retryCount, rl)
 }
@@ -142,6 +160,21 @@ func RequestWithRetryAndReadContext(ctx context.Context, method string, url stri
 	return requestWithRetryAndRead(ctx, method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, nil)
 }
 
+// RequestWithRetryAndReadMeta is RequestWithRetryAndRead, also returning the
+// final status, headers, request id, attempt count and total latency.
+//
+// The meta is returned on error as well, and is also attached to any C7Error
+// as its Response field.
+func RequestWithRetryAndReadMeta(method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]byte, *ResponseMeta, error) {
+	return requestWithRetryAndReadMeta(context.Background(), method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, nil)
+}
+
+// RequestWithRetryAndReadMetaContext is RequestWithRetryAndReadMeta with a
+// caller-supplied context.
+func RequestWithRetryAndReadMetaContext(ctx context.Context, method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]byte, *ResponseMeta, error) {
+	return requestWithRetryAndReadMeta(ctx, method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, nil)
+}
+
 // Returns the fulfillment ids if there is any fulfillments on a C7 order.
 //
 // Usually this will return just one, but can return multiple if there are partial fulfillments or errors with C7.
@@ -182,8 +215,28 @@ func GetFulfillmentIds(OrderNumber int, tenant string, auth string, attempts int
 
 }
 
+// ErrNoFulfillments is returned by GetFulfillmentsByOrderNumber when the order
+// exists but has not been fulfilled.
+var ErrNoFulfillments = errors.New("no fulfillments found")
+
 func GetFulfillmentsByOrderNumber(OrderNumber int, tenant string, auth string, attempts int, rl genericRateLimiter) (*[]C7OrderFulfillment, error) {
 
+	order, err := getOrderByNumber(OrderNumber, tenant, auth, attempts, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order.Fulfillments) == 0 {
+		return nil, ErrNoFulfillments
+	}
+	return &order.Fulfillments, nil
+
+}
+
+// getOrderByNumber searches for an order by number, since the API only gets
+// orders directly by id.
+func getOrderByNumber(OrderNumber int, tenant string, auth string, attempts int, rl genericRateLimiter) (*C7Order, error) {
+
 	orderUrl := Endpoints.Order + "?q=" + strconv.Itoa(OrderNumber)
 	// Get the order from C7
 	ordersBytes, err := RequestWithRetryAndRead("GET", orderUrl, nil, nil, tenant, auth, attempts, rl)
@@ -198,16 +251,12 @@ func GetFulfillmentsByOrderNumber(OrderNumber int, tenant string, auth string, a
 		return nil, err
 	}
 
-	// Get the fulfillment ID
 	if len(orders.Orders) == 0 {
 		return nil, errors.New("no orders found")
 	}
-	if len(orders.Orders[0].Fulfillments) == 0 {
-		return nil, errors.New("no fulfillments found")
-	}
-	for _, order := range orders.Orders {
-		if order.OrderNumber == OrderNumber {
-			return &order.Fulfillments, nil
+	for i := range orders.Orders {
+		if orders.Orders[i].OrderNumber == OrderNumber {
+			return &orders.Orders[i], nil
 		}
 	}
 	return nil, errors.New("no matching order found")
@@ -248,21 +297,11 @@ func MarkNoFulfillmentRequired(orderId string, shipTime time.Time, tenant string
 	return nil
 }
 
+// IsCarrierSupported reports whether carrier, or one of its aliases, is in the
+// carrier registry. See LookupCarrier.
 func IsCarrierSupported(carrier string) bool {
-	switch strings.ToUpper(carrier) {
-	case "UPS":
-		return true
-	case "FEDEX":
-		return true
-	case "GSO":
-		return true
-	case "ATS HEALTHCARE":
-		return true
-	case "AUSTRALIA POST":
-		return true
-	default:
-		return false
-	}
+	_, ok := LookupCarrier(carrier)
+	return ok
 }
 
 func ResponseIsOK(statusCode int) bool {
@@ -0,0 +1,185 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CreateCart opens an empty cart for a customer.
+func CreateCart(customerId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if customerId == "" {
+		return nil, errors.New("no customerId provided")
+	}
+
+	resp, err := Post(&CartPost{CustomerID: customerId}, Endpoints.Cart, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cart: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+func GetCartById(cartId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if cartId == "" {
+		return nil, errors.New("no cartId provided")
+	}
+
+	cart, err := Get[C7Cart](Endpoints.Cart+"/"+cartId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	return cart, nil
+}
+
+// AddCartItem adds quantity of a variant to the cart and returns the updated
+// cart.
+func AddCartItem(cartId string, productVariantId string, quantity int, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if cartId == "" || productVariantId == "" {
+		return nil, errors.New("cartId and productVariantId are required")
+	}
+	if quantity <= 0 {
+		return nil, errors.New("quantity must be positive")
+	}
+
+	payload := CartItemPost{ProductVariantID: productVariantId, Quantity: quantity}
+	resp, err := Post(&payload, Endpoints.Cart+"/"+cartId+"/item", tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add cart item: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+// UpdateCartItem sets the quantity of an item already in the cart. A quantity
+// of zero removes it.
+func UpdateCartItem(cartId string, cartItemId string, quantity int, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if quantity == 0 {
+		return RemoveCartItem(cartId, cartItemId, tenant, c7AppAuthEncoded, retryCount, rl)
+	}
+	if cartId == "" || cartItemId == "" {
+		return nil, errors.New("cartId and cartItemId are required")
+	}
+	if quantity < 0 {
+		return nil, errors.New("quantity cannot be negative")
+	}
+
+	payload := CartItemPut{Quantity: quantity}
+	resp, err := Put(&payload, Endpoints.Cart+"/"+cartId+"/item/"+cartItemId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cart item: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+func RemoveCartItem(cartId string, cartItemId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if cartId == "" || cartItemId == "" {
+		return nil, errors.New("cartId and cartItemId are required")
+	}
+
+	resp, err := RequestWithRetryAndRead(http.MethodDelete, Endpoints.Cart+"/"+cartId+"/item/"+cartItemId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove cart item: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+// ApplyCartCoupon applies a coupon code. Commerce7 rejects codes that don't
+// exist or don't apply to the cart, which surfaces as a C7Error.
+func ApplyCartCoupon(cartId string, code string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if cartId == "" || code == "" {
+		return nil, errors.New("cartId and coupon code are required")
+	}
+
+	payload := CartCouponPost{Code: code}
+	resp, err := Post(&payload, Endpoints.Cart+"/"+cartId+"/coupon", tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply coupon: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+// SetCartShipTo sets the ship-to address and switches the cart to shipping.
+func SetCartShipTo(cartId string, shipTo *Address, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if shipTo == nil {
+		return nil, errors.New("shipTo cannot be nil")
+	}
+	if shipTo.Address == "" || shipTo.City == "" || shipTo.StateCode == "" || shipTo.ZipCode == "" || shipTo.CountryCode == "" {
+		return nil, errors.New("shipTo requires address, city, stateCode, zipCode and countryCode")
+	}
+
+	return putCartShipping(cartId, &CartShippingPut{
+		OrderDeliveryMethod: OrderDeliveryMethodShip,
+		ShipTo:              shipTo,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// SetCartShippingMethod picks the shipping service for a cart that already
+// has a ship-to address.
+func SetCartShippingMethod(cartId string, shippingServiceId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if shippingServiceId == "" {
+		return nil, errors.New("no shippingServiceId provided")
+	}
+
+	return putCartShipping(cartId, &CartShippingPut{ShippingServiceID: shippingServiceId}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// CheckoutCart converts the cart into an order, paid with tenders, and returns
+// the order.
+func CheckoutCart(cartId string, tenders []TenderPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	if cartId == "" {
+		return nil, errors.New("no cartId provided")
+	}
+	if err := validateTenders(tenders); err != nil {
+		return nil, err
+	}
+
+	payload := CartCheckoutPost{Tenders: tenders}
+	resp, err := Post(&payload, Endpoints.Cart+"/"+cartId+"/checkout", tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check out cart: %w", err)
+	}
+
+	var order C7Order
+	if err := json.Unmarshal(*resp, &order); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order after checkout: %w", err)
+	}
+	return &order, nil
+}
+
+func putCartShipping(cartId string, payload *CartShippingPut, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Cart, error) {
+	if cartId == "" {
+		return nil, errors.New("no cartId provided")
+	}
+
+	resp, err := Put(payload, Endpoints.Cart+"/"+cartId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cart shipping: %w", err)
+	}
+	return unmarshalCart(resp)
+}
+
+func unmarshalCart(resp *[]byte) (*C7Cart, error) {
+	var cart C7Cart
+	if err := json.Unmarshal(*resp, &cart); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cart: %w", err)
+	}
+	return &cart, nil
+}
+
+func validateTenders(tenders []TenderPost) error {
+	if len(tenders) == 0 {
+		return errors.New("at least one tender is required")
+	}
+	for i, tender := range tenders {
+		if !IsValidTenderType(tender.TenderType) {
+			return fmt.Errorf("tender %d: invalid tender type: %s", i, tender.TenderType)
+		}
+		if tender.AmountTendered.Cents <= 0 {
+			return fmt.Errorf("tender %d: amount must be positive", i)
+		}
+		if tender.TenderType == TenderTypeGiftCard && tender.GiftCardCode == "" {
+			return fmt.Errorf("tender %d: gift card tenders require a giftCardCode", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCart_BuildAndCheckout(t *testing.T) {
+	var calls []string
+	var checkout CartCheckoutPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/cart/cart1/checkout":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &checkout)
+			w.Write([]byte(`{"id":"o1","orderNumber":1042,"total":4500}`))
+		default:
+			w.Write([]byte(`{"id":"cart1","items":[{"id":"i1","sku":"CAB21-750","quantity":2,"price":4500}],"total":9000}`))
+		}
+	}))
+
+	cart, err := AddCartItem("cart1", "v1", 2, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item := cart.ItemBySku("CAB21-750")
+	if item == nil || item.Quantity != 2 || item.Price.Cents != 4500 {
+		t.Fatalf("cart = %+v", cart)
+	}
+
+	if _, err := UpdateCartItem("cart1", item.ID, 0, "t", "a", 0, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := CheckoutCart("cart1", []TenderPost{{TenderType: "IOU", AmountTendered: Money{Cents: 4500}}}, "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for an invalid tender type")
+	}
+
+	// Cart totals are Money, so they can be tendered as they are.
+	order, err := CheckoutCart("cart1", []TenderPost{{TenderType: TenderTypeCash, AmountTendered: cart.Total}}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.OrderNumber != 1042 || checkout.Tenders[0].TenderType != TenderTypeCash || checkout.Tenders[0].AmountTendered.Cents != 9000 {
+		t.Errorf("order = %+v, checkout = %+v", order, checkout)
+	}
+
+	want := []string{"POST /cart/cart1/item", "DELETE /cart/cart1/item/i1", "POST /cart/cart1/checkout"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %s, want %s", i, calls[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClubMembershipFilter narrows GetClubMemberships. Blank fields are ignored.
+type ClubMembershipFilter struct {
+	ClubID     string
+	CustomerID string
+	Status     string // See ClubMembershipStatus constants
+}
+
+func (f ClubMembershipFilter) queries() map[string]string {
+	queries := map[string]string{}
+	if f.ClubID != "" {
+		queries["clubId"] = f.ClubID
+	}
+	if f.CustomerID != "" {
+		queries["customerId"] = f.CustomerID
+	}
+	if f.Status != "" {
+		queries["status"] = f.Status
+	}
+	return queries
+}
+
+// GetClubMemberships returns every membership matching filter, walking all
+// pages.
+func GetClubMemberships(filter ClubMembershipFilter, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]C7ClubMembership, error) {
+	if filter.Status != "" && !IsValidClubMembershipStatus(filter.Status) {
+		return nil, fmt.Errorf("invalid club membership status: %s", filter.Status)
+	}
+
+	memberships, err := GetAll[C7ClubMembership, C7ClubMemberships](Endpoints.ClubMembership, filter.queries(), nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get club memberships: %w", err)
+	}
+	return memberships, nil
+}
+
+func GetClubMembershipById(membershipId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	if membershipId == "" {
+		return nil, errors.New("no membershipId provided")
+	}
+
+	membership, err := Get[C7ClubMembership](Endpoints.ClubMembership+"/"+membershipId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get club membership: %w", err)
+	}
+	return membership, nil
+}
+
+// CreateClubMembership signs a customer up to a club.
+func CreateClubMembership(membership *ClubMembershipPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	if err := validateClubMembershipPost(membership); err != nil {
+		return nil, err
+	}
+
+	resp, err := Post(membership, Endpoints.ClubMembership, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create club membership: %w", err)
+	}
+
+	var created C7ClubMembership
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal club membership after create: %w", err)
+	}
+	return &created, nil
+}
+
+// HoldClubMembership puts a membership on hold from start until end. The
+// membership skips any club releases in that window.
+func HoldClubMembership(membershipId string, start time.Time, end time.Time, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	if !end.After(start) {
+		return nil, errors.New("hold end date must be after the start date")
+	}
+
+	return putClubMembershipStatus(membershipId, &ClubMembershipStatusPut{
+		Status:          ClubMembershipStatusOnHold,
+		OnHoldStartDate: &start,
+		OnHoldEndDate:   &end,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// CancelClubMembership cancels a membership as of now. Commerce7 requires a
+// cancellation reason; comments are optional.
+func CancelClubMembership(membershipId string, reason string, comments string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	if reason == "" {
+		return nil, errors.New("a cancellation reason is required")
+	}
+
+	now := time.Now().UTC()
+	return putClubMembershipStatus(membershipId, &ClubMembershipStatusPut{
+		Status:               ClubMembershipStatusCancelled,
+		CancelDate:           &now,
+		CancellationReason:   reason,
+		CancellationComments: comments,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// ReactivateClubMembership returns a held or cancelled membership to Active.
+func ReactivateClubMembership(membershipId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	return putClubMembershipStatus(membershipId, &ClubMembershipStatusPut{
+		Status: ClubMembershipStatusActive,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+func putClubMembershipStatus(membershipId string, payload *ClubMembershipStatusPut, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7ClubMembership, error) {
+	if membershipId == "" {
+		return nil, errors.New("no membershipId provided")
+	}
+
+	resp, err := Put(payload, Endpoints.ClubMembership+"/"+membershipId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set club membership status to %s: %w", payload.Status, err)
+	}
+
+	var updated C7ClubMembership
+	if err := json.Unmarshal(*resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal club membership after update: %w", err)
+	}
+	return &updated, nil
+}
+
+func validateClubMembershipPost(membership *ClubMembershipPost) error {
+	if membership == nil {
+		return errors.New("club membership cannot be nil")
+	}
+	if membership.CustomerID == "" {
+		return errors.New("no customerId provided")
+	}
+	if membership.ClubID == "" {
+		return errors.New("no clubId provided")
+	}
+
+	switch membership.OrderDeliveryMethod {
+	case OrderDeliveryMethodShip:
+		if membership.ShipToCustomerAddressID == "" {
+			return errors.New("ship memberships require a shipToCustomerAddressId")
+		}
+	case OrderDeliveryMethodPickup:
+		if membership.PickupInventoryLocationID == "" {
+			return errors.New("pickup memberships require a pickupInventoryLocationId")
+		}
+	default:
+		return fmt.Errorf("invalid club membership delivery method: %s", membership.OrderDeliveryMethod)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHoldClubMembership(t *testing.T) {
+	var got map[string]any
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/club-membership/m1" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.Write([]byte(`{"id":"m1","status":"On Hold"}`))
+	}))
+
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+
+	if _, err := HoldClubMembership("m1", end, start, "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for a hold that ends before it starts")
+	}
+
+	membership, err := HoldClubMembership("m1", start, end, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if membership.Status != ClubMembershipStatusOnHold {
+		t.Errorf("status = %q", membership.Status)
+	}
+	if got["status"] != ClubMembershipStatusOnHold || got["onHoldEndDate"] != "2026-09-01T00:00:00Z" {
+		t.Errorf("sent %v", got)
+	}
+	// Cancellation fields must not ride along on a hold.
+	if _, ok := got["cancellationReason"]; ok {
+		t.Errorf("hold sent cancellation fields: %v", got)
+	}
+}
+
+func TestGetClubMemberships_Filter(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("clubId") != "c1" || q.Get("status") != ClubMembershipStatusActive || q.Has("customerId") {
+			t.Errorf("query = %v", q)
+		}
+		w.Write([]byte(`{"total":1,"clubMemberships":[{"id":"m1","clubId":"c1","status":"Active"}]}`))
+	}))
+
+	memberships, err := GetClubMemberships(ClubMembershipFilter{ClubID: "c1", Status: ClubMembershipStatusActive}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*memberships) != 1 {
+		t.Errorf("got %d memberships, want 1", len(*memberships))
+	}
+
+	if _, err := GetClubMemberships(ClubMembershipFilter{Status: "Paused"}, "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for an invalid status")
+	}
+}
+
+// An unset signup date is left out rather than sent as the zero time.
+func TestCreateClubMembership_OmitsUnsetSignupDate(t *testing.T) {
+	var got map[string]any
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.Write([]byte(`{"id":"m1","status":"Active"}`))
+	}))
+
+	membership := &ClubMembershipPost{CustomerID: "cu1", ClubID: "c1", OrderDeliveryMethod: OrderDeliveryMethodPickup, PickupInventoryLocationID: "l1"}
+	if _, err := CreateClubMembership(membership, "t", "a", 0, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["signupDate"]; ok {
+		t.Errorf("sent signupDate %v for an unset date", got["signupDate"])
+	}
+}
@@ -0,0 +1,176 @@
+package c7api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ClubRetentionReport holds monthly churn figures for each club.
+type ClubRetentionReport struct {
+	Location *time.Location // Timezone the months were bucketed in
+	Rows     []ClubRetentionRow
+}
+
+// ClubRetentionRow is one club's figures for one calendar month, in the
+// winery's timezone.
+type ClubRetentionRow struct {
+	ClubID            string
+	ClubTitle         string
+	Month             string // "2006-01"
+	NewSignups        int
+	Cancellations     int
+	Holds             int     // Holds that started this month
+	NetGrowth         int     // NewSignups - Cancellations
+	ActiveAtEnd       int     // Members signed up and not cancelled at the end of the month
+	AverageTenureDays float64 // Mean days since signup of ActiveAtEnd members, at the end of the month
+}
+
+// GetClubRetentionReport fetches every club membership and reports monthly
+// retention for each club from the month containing from through the month
+// containing to, bucketed in the winery's timezone from its settings.
+func GetClubRetentionReport(from time.Time, to time.Time, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*ClubRetentionReport, error) {
+	settings, err := GetWineryInfoSettings(tenant, c7AppAuthEncoded, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("while loading winery timezone %q: %w", settings.TimeZone, err)
+	}
+
+	clubs, err := GetClubs(nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := GetClubMemberships(ClubMembershipFilter{}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildClubRetentionReport(*clubs, *memberships, loc, from, to)
+}
+
+// BuildClubRetentionReport computes the report from memberships already in
+// hand. Clubs supply the titles and the set of clubs reported on; a club with
+// no memberships still gets rows of zeros.
+func BuildClubRetentionReport(clubs []C7Club, memberships []C7ClubMembership, loc *time.Location, from time.Time, to time.Time) (*ClubRetentionReport, error) {
+	if loc == nil {
+		return nil, errors.New("no timezone provided")
+	}
+	if to.Before(from) {
+		return nil, errors.New("report end is before its start")
+	}
+
+	titles := map[string]string{}
+	for _, club := range clubs {
+		titles[club.ID] = club.Title
+	}
+	byClub := map[string][]C7ClubMembership{}
+	for _, membership := range memberships {
+		byClub[membership.ClubID] = append(byClub[membership.ClubID], membership)
+		if _, ok := titles[membership.ClubID]; !ok && membership.Club != nil {
+			titles[membership.ClubID] = membership.Club.Title
+		}
+	}
+
+	clubIds := make([]string, 0, len(titles))
+	for id := range titles {
+		clubIds = append(clubIds, id)
+	}
+	sort.Slice(clubIds, func(i, j int) bool {
+		if titles[clubIds[i]] != titles[clubIds[j]] {
+			return titles[clubIds[i]] < titles[clubIds[j]]
+		}
+		return clubIds[i] < clubIds[j]
+	})
+
+	report := &ClubRetentionReport{Location: loc}
+
+	first := startOfMonth(from.In(loc))
+	last := startOfMonth(to.In(loc))
+
+	for _, clubId := range clubIds {
+		for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+			next := month.AddDate(0, 1, 0)
+			row := ClubRetentionRow{
+				ClubID:    clubId,
+				ClubTitle: titles[clubId],
+				Month:     month.Format("2006-01"),
+			}
+
+			var tenureTotal float64
+			for _, m := range byClub[clubId] {
+				if inRange(m.SignupDate, month, next) {
+					row.NewSignups++
+				}
+				if m.CancelDate != nil && inRange(*m.CancelDate, month, next) {
+					row.Cancellations++
+				}
+				if m.OnHoldStartDate != nil && inRange(*m.OnHoldStartDate, month, next) {
+					row.Holds++
+				}
+
+				signedUp := m.SignupDate.Before(next)
+				cancelled := m.CancelDate != nil && m.CancelDate.Before(next)
+				if signedUp && !cancelled {
+					row.ActiveAtEnd++
+					tenureTotal += next.Sub(m.SignupDate).Hours() / 24
+				}
+			}
+
+			row.NetGrowth = row.NewSignups - row.Cancellations
+			if row.ActiveAtEnd > 0 {
+				row.AverageTenureDays = tenureTotal / float64(row.ActiveAtEnd)
+			}
+			report.Rows = append(report.Rows, row)
+		}
+	}
+
+	return report, nil
+}
+
+// WriteCSV writes the report with a header row, one row per club and month.
+func (r *ClubRetentionReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"club_id", "club_title", "month", "new_signups", "cancellations", "holds", "net_growth", "active_at_end", "average_tenure_days"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		record := []string{
+			row.ClubID,
+			row.ClubTitle,
+			row.Month,
+			strconv.Itoa(row.NewSignups),
+			strconv.Itoa(row.Cancellations),
+			strconv.Itoa(row.Holds),
+			strconv.Itoa(row.NetGrowth),
+			strconv.Itoa(row.ActiveAtEnd),
+			strconv.FormatFloat(row.AverageTenureDays, 'f', 1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// inRange reports whether t falls in [start, end).
+func inRange(t time.Time, start time.Time, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
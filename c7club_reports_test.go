@@ -0,0 +1,65 @@
+package c7api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildClubRetentionReport(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("timezone data not available:", err)
+	}
+
+	utc := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	ptr := func(v time.Time) *time.Time { return &v }
+
+	clubs := []C7Club{{ID: "c1", Title: "Cellar Club"}, {ID: "c2", Title: "Allocation"}}
+	memberships := []C7ClubMembership{
+		// 2026-02-01T05:00Z is still January 31st in Los Angeles.
+		{ClubID: "c1", SignupDate: utc("2026-02-01T05:00:00Z")},
+		{ClubID: "c1", SignupDate: utc("2026-02-10T18:00:00Z"), CancelDate: ptr(utc("2026-02-20T18:00:00Z"))},
+		{ClubID: "c1", SignupDate: utc("2025-06-01T18:00:00Z"), OnHoldStartDate: ptr(utc("2026-02-05T18:00:00Z"))},
+	}
+
+	report, err := BuildClubRetentionReport(clubs, memberships, loc, utc("2026-01-15T00:00:00Z"), utc("2026-02-15T00:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Two clubs x two months, sorted by title.
+	if len(report.Rows) != 4 {
+		t.Fatalf("got %d rows, want 4: %+v", len(report.Rows), report.Rows)
+	}
+	if report.Rows[0].ClubID != "c2" || report.Rows[0].NewSignups != 0 {
+		t.Errorf("empty club row = %+v", report.Rows[0])
+	}
+
+	jan, feb := report.Rows[2], report.Rows[3]
+	if jan.Month != "2026-01" || jan.NewSignups != 1 || jan.ActiveAtEnd != 2 {
+		t.Errorf("january = %+v", jan)
+	}
+	if feb.Month != "2026-02" || feb.NewSignups != 1 || feb.Cancellations != 1 || feb.Holds != 1 || feb.NetGrowth != 0 || feb.ActiveAtEnd != 2 {
+		t.Errorf("february = %+v", feb)
+	}
+	if feb.AverageTenureDays <= 0 {
+		t.Errorf("february average tenure = %v", feb.AverageTenureDays)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[4], "c1,Cellar Club,2026-02,1,1,1,0,2,") {
+		t.Errorf("csv = %q", buf.String())
+	}
+}
@@ -0,0 +1,96 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GetClubs returns every club matching queries, walking all pages.
+func GetClubs(queries map[string]string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]C7Club, error) {
+	clubs, err := GetAll[C7Club, C7Clubs](Endpoints.Club, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clubs: %w", err)
+	}
+	return clubs, nil
+}
+
+func GetClubById(clubId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Club, error) {
+	if clubId == "" {
+		return nil, errors.New("no clubId provided")
+	}
+
+	club, err := Get[C7Club](Endpoints.Club+"/"+clubId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get club: %w", err)
+	}
+	return club, nil
+}
+
+// CreateClub validates club client-side and posts it.
+func CreateClub(club *ClubPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Club, error) {
+	if err := ValidateClubPost(club); err != nil {
+		return nil, err
+	}
+
+	resp, err := Post(club, Endpoints.Club, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create club: %w", err)
+	}
+
+	var created C7Club
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal club after create: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdateClub validates club client-side and puts it over clubId.
+func UpdateClub(clubId string, club *ClubPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Club, error) {
+	if clubId == "" {
+		return nil, errors.New("no clubId provided")
+	}
+	if err := ValidateClubPost(club); err != nil {
+		return nil, err
+	}
+
+	resp, err := Put(club, Endpoints.Club+"/"+clubId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update club: %w", err)
+	}
+
+	var updated C7Club
+	if err := json.Unmarshal(*resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal club after update: %w", err)
+	}
+	return &updated, nil
+}
+
+// ValidateClubPost checks the club against the enums Commerce7 enforces.
+func ValidateClubPost(club *ClubPost) error {
+	if club == nil {
+		return errors.New("club cannot be nil")
+	}
+	if club.Title == "" {
+		return errors.New("club title is required")
+	}
+	if !IsValidClubType(club.Type) {
+		return fmt.Errorf("invalid club type %q, must be one of: %s", club.Type, strings.Join(GetValidClubTypes(), ", "))
+	}
+	if !IsValidClubAdminStatus(club.AdminStatus) {
+		return fmt.Errorf("invalid club admin status: %s", club.AdminStatus)
+	}
+	if !IsValidClubWebStatus(club.WebStatus) {
+		return fmt.Errorf("invalid club web status: %s", club.WebStatus)
+	}
+
+	options := club.ShippingOptions
+	if !options.IsShippingAvailable && !options.IsPickupAvailable {
+		return errors.New("club must allow shipping, pickup, or both")
+	}
+	if !options.IsPickupAvailable && len(options.PickupInventoryLocationIDs) > 0 {
+		return errors.New("club has pickup locations but pickup is not available")
+	}
+	return nil
+}
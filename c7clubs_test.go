@@ -0,0 +1,68 @@
+package c7api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestValidateClubPost(t *testing.T) {
+	valid := func() *ClubPost {
+		return &ClubPost{
+			Title:           "Cellar Club",
+			Type:            ClubTypeTraditional,
+			AdminStatus:     ClubAdminStatusActive,
+			WebStatus:       ClubWebStatusNotActive,
+			ShippingOptions: ClubShippingOptions{IsShippingAvailable: true},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *ClubPost)
+		wantErr bool
+	}{
+		{"valid", func(c *ClubPost) {}, false},
+		{"bad type", func(c *ClubPost) { c.Type = "Allocation" }, true},
+		{"bad admin status", func(c *ClubPost) { c.AdminStatus = "Active" }, true},
+		{"no delivery", func(c *ClubPost) { c.ShippingOptions.IsShippingAvailable = false }, true},
+		{"pickup locations without pickup", func(c *ClubPost) { c.ShippingOptions.PickupInventoryLocationIDs = []string{"l1"} }, true},
+		{"pickup only", func(c *ClubPost) {
+			c.ShippingOptions = ClubShippingOptions{IsPickupAvailable: true, PickupInventoryLocationIDs: []string{"l1"}}
+		}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid()
+			tc.mutate(c)
+			if err := ValidateClubPost(c); (err != nil) != tc.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetClubById(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/club/c1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"c1","title":"Cellar Club","type":"Traditional","adminStatus":"Available","webStatus":"Available",
+			"shippingOptions":{"isShippingAvailable":true,"isPickupAvailable":true,"pickupInventoryLocationIds":["l1"]}}`))
+	}))
+
+	club, err := GetClubById("c1", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.Type != ClubTypeTraditional || !club.ShippingOptions.IsPickupAvailable || club.ShippingOptions.PickupInventoryLocationIDs[0] != "l1" {
+		t.Errorf("club = %+v", club)
+	}
+	if err := ValidateClubPost(&ClubPost{}); err == nil {
+		t.Error("expected an empty club to fail validation")
+	}
+	post := club.ToPost()
+	if err := ValidateClubPost(&post); err != nil {
+		t.Errorf("round-tripped club failed validation: %v", err)
+	}
+}
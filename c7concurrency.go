@@ -0,0 +1,215 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrConflict is matched by errors.Is on every *ConflictError.
+var ErrConflict = errors.New("resource was modified since it was read")
+
+// ConflictError is returned by the guarded updates when the resource's
+// updatedAt no longer matches the version the caller based its change on,
+// meaning someone else wrote it in between. Nothing is sent in that case;
+// re-read the resource, reapply the change and try again.
+type ConflictError struct {
+	Resource string // e.g. "customer", "product"
+	ID       string
+	Expected time.Time // updatedAt the caller read
+	Actual   time.Time // updatedAt found on re-read
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified at %s, after the version read at %s",
+		e.Resource, e.ID, e.Actual.Format(time.RFC3339Nano), e.Expected.Format(time.RFC3339Nano))
+}
+
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+// UpdateIfUnchanged re-reads a resource with read and, if its updatedAt still
+// equals basedOn, calls write. Otherwise it returns a *ConflictError without
+// writing.
+//
+// Commerce7 has no conditional PUT, so this narrows the window for lost
+// updates to the time between the re-read and the write rather than closing
+// it entirely.
+func UpdateIfUnchanged[T any, R any](resource, id string, basedOn time.Time, read func() (*T, error), updatedAt func(*T) (time.Time, error), write func(current *T) (*R, error)) (*R, error) {
+	if id == "" {
+		return nil, fmt.Errorf("no %s id provided", resource)
+	}
+	if basedOn.IsZero() {
+		return nil, fmt.Errorf("no updatedAt provided to guard the %s update", resource)
+	}
+
+	current, err := read()
+	if err != nil {
+		return nil, err
+	}
+	actual, err := updatedAt(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read updatedAt of %s %s: %w", resource, id, err)
+	}
+	if !actual.Equal(basedOn) {
+		return nil, &ConflictError{Resource: resource, ID: id, Expected: basedOn, Actual: actual}
+	}
+
+	return write(current)
+}
+
+// customerReadOnlyFields are properties of a customer that Commerce7
+// computes or manages through other endpoints, so they are left out when a
+// CustomerFull is put back.
+var customerReadOnlyFields = []string{
+	"id", "createdAt", "updatedAt", "lastActivityDate", "orderInformation",
+	"loyalty", "loginActivity", "clubs", "products",
+}
+
+// UpdateCustomerIfUnchanged puts customer back over its own Id, provided the
+// stored customer still has the UpdatedAt it was read with. customer should
+// be the CustomerFull as read and then changed: fields it doesn't model, at
+// any depth, are sent back as they were read, and read-only fields are left
+// out.
+func UpdateCustomerIfUnchanged(customer *CustomerFull, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*CustomerFull, error) {
+	if customer == nil {
+		return nil, errors.New("customer cannot be nil")
+	}
+	basedOn, err := parseUpdatedAt(customer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := customerPutPayload(customer)
+	if err != nil {
+		return nil, err
+	}
+
+	reqUrl := Endpoints.Customer + "/" + customer.Id
+	return UpdateIfUnchanged("customer", customer.Id, basedOn,
+		func() (*CustomerFull, error) {
+			return getCustomerFull(customer.Id, tenant, c7AppAuthEncoded, retryCount, rl)
+		},
+		func(c *CustomerFull) (time.Time, error) { return parseUpdatedAt(c.UpdatedAt) },
+		func(*CustomerFull) (*CustomerFull, error) {
+			resp, err := RequestWithRetryAndRead(http.MethodPut, reqUrl, nil, &payload, tenant, c7AppAuthEncoded, retryCount, rl)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update customer: %w", err)
+			}
+
+			var updated CustomerFull
+			if err := json.Unmarshal(*resp, &updated); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal customer after update: %w", err)
+			}
+			return &updated, nil
+		})
+}
+
+// PutCustomerMetaDataIfUnchanged is PutCustomerMetaData for the customer
+// read as based, refused with a *ConflictError if that customer has changed
+// since.
+func PutCustomerMetaDataIfUnchanged(metaData map[string]any, based *CustomerFull, tenant, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*Customer, error) {
+	if based == nil {
+		return nil, errors.New("customer cannot be nil")
+	}
+	basedOn, err := parseUpdatedAt(based.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return UpdateIfUnchanged("customer", based.Id, basedOn,
+		func() (*CustomerFull, error) {
+			return getCustomerFull(based.Id, tenant, c7AppAuthEncoded, retryCount, rl)
+		},
+		func(c *CustomerFull) (time.Time, error) { return parseUpdatedAt(c.UpdatedAt) },
+		func(*CustomerFull) (*Customer, error) {
+			return PutCustomerMetaData(metaData, based.Id, tenant, c7AppAuthEncoded, retryCount, rl)
+		})
+}
+
+// UpdateProductIfUnchanged is UpdateProduct for the product read as based,
+// refused with a *ConflictError if that product has changed since.
+func UpdateProductIfUnchanged(based *C7Product, product *ProductPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, error) {
+	if based == nil {
+		return nil, errors.New("product cannot be nil")
+	}
+	// Validate before the re-read so a bad payload doesn't cost a request.
+	if err := ValidateProductPost(product); err != nil {
+		return nil, err
+	}
+
+	return UpdateIfUnchanged("product", based.ID, based.UpdatedAt,
+		func() (*C7Product, error) {
+			return GetProductById(based.ID, tenant, c7AppAuthEncoded, retryCount, rl)
+		},
+		func(p *C7Product) (time.Time, error) { return p.UpdatedAt, nil },
+		func(*C7Product) (*C7Product, error) {
+			return UpdateProduct(based.ID, product, tenant, c7AppAuthEncoded, retryCount, rl)
+		})
+}
+
+// PutMetaDataConfigIfUnchanged is PutMetaDataConfig for the config read as
+// based, refused with a *ConflictError if that config has changed since.
+func PutMetaDataConfigIfUnchanged(objectPayload *MetaDataConfigPut, objectType string, based *MetaDataConfig, tenant, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*MetaDataConfig, error) {
+	if based == nil {
+		return nil, errors.New("metadata config cannot be nil")
+	}
+	basedOn, err := parseUpdatedAt(based.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return UpdateIfUnchanged("metadata config", based.Id, basedOn,
+		func() (*MetaDataConfig, error) {
+			return GetMetaDataConfigById(based.Id, objectType, tenant, c7AppAuthEncoded, retryCount, rl)
+		},
+		func(m *MetaDataConfig) (time.Time, error) { return parseUpdatedAt(m.UpdatedAt) },
+		func(*MetaDataConfig) (*MetaDataConfig, error) {
+			return PutMetaDataConfig(objectPayload, objectType, based.Id, tenant, c7AppAuthEncoded, retryCount, rl)
+		})
+}
+
+func getCustomerFull(customerId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*CustomerFull, error) {
+	customer, err := Get[CustomerFull](Endpoints.Customer+"/"+customerId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	return customer, nil
+}
+
+// customerPutPayload encodes customer, which keeps everything Commerce7 sent
+// that CustomerFull doesn't model, and strips the read-only fields.
+func customerPutPayload(customer *CustomerFull) ([]byte, error) {
+	data, err := json.Marshal(customer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal customer: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to marshal customer: %w", err)
+	}
+	for _, key := range customerReadOnlyFields {
+		delete(fields, key)
+	}
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal customer: %w", err)
+	}
+	return payload, nil
+}
+
+// parseUpdatedAt parses the string updatedAt carried by the older structs,
+// so versions compare by instant rather than by formatting.
+func parseUpdatedAt(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("no updatedAt provided")
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid updatedAt %q: %w", s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,189 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// customerServer serves a single customer whose updatedAt is stored, and
+// records the body of any PUT.
+func customerServer(t *testing.T, storedUpdatedAt string) (puts *int32, body *map[string]any) {
+	t.Helper()
+	puts = new(int32)
+	body = &map[string]any{}
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/customer/c1" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"c1","firstName":"Ada","updatedAt":"` + storedUpdatedAt + `","loyaltyNote":"keep","emails":[{"id":"e1","email":"ada@example.com","isVerified":true}]}`))
+		case http.MethodPut:
+			atomic.AddInt32(puts, 1)
+			raw, _ := io.ReadAll(r.Body)
+			json.Unmarshal(raw, body)
+			w.Write([]byte(`{"id":"c1","firstName":"Grace","updatedAt":"2026-10-18T10:05:00.000Z"}`))
+		}
+	}))
+	return puts, body
+}
+
+func TestUpdateCustomerIfUnchanged(t *testing.T) {
+	puts, body := customerServer(t, "2026-10-18T10:00:00.000Z")
+
+	var customer CustomerFull
+	json.Unmarshal([]byte(`{
+		"id": "c1",
+		"firstName": "Ada",
+		"createdAt": "2020-01-01T00:00:00Z",
+		"updatedAt": "2026-10-18T10:00:00Z",
+		"loyaltyNote": "keep",
+		"emails": [{"id": "e1", "email": "ada@example.com", "isVerified": true}],
+		"orderInformation": {"orderCount": 3},
+		"clubs": [{"clubId": "club1"}]
+	}`), &customer)
+	customer.Firstname = "Grace"
+
+	// The stored value is formatted differently but is the same instant.
+	updated, err := UpdateCustomerIfUnchanged(&customer, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *puts != 1 || updated.Firstname != "Grace" {
+		t.Errorf("puts = %d, updated = %+v", *puts, updated)
+	}
+	if (*body)["firstName"] != "Grace" || (*body)["loyaltyNote"] != "keep" {
+		t.Errorf("put body = %v", *body)
+	}
+	// A property the nested Email doesn't model must survive.
+	emails, _ := (*body)["emails"].([]any)
+	if len(emails) != 1 || emails[0].(map[string]any)["isVerified"] != true {
+		t.Errorf("put emails = %v", (*body)["emails"])
+	}
+	for _, key := range []string{"id", "createdAt", "updatedAt", "orderInformation", "clubs"} {
+		if _, ok := (*body)[key]; ok {
+			t.Errorf("put read-only field %s", key)
+		}
+	}
+}
+
+func TestUpdateCustomerIfUnchanged_Conflict(t *testing.T) {
+	puts, _ := customerServer(t, "2026-10-18T10:03:00.000Z")
+
+	customer := CustomerFull{Customer: Customer{Id: "c1", Firstname: "Grace"}, UpdatedAt: "2026-10-18T10:00:00.000Z"}
+	_, err := UpdateCustomerIfUnchanged(&customer, "t", "a", 0, nil)
+
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want a *ConflictError", err)
+	}
+	if !errors.Is(err, ErrConflict) {
+		t.Error("expected errors.Is(err, ErrConflict)")
+	}
+	if conflict.Resource != "customer" || conflict.ID != "c1" || conflict.Actual.Minute() != 3 {
+		t.Errorf("conflict = %+v", conflict)
+	}
+	if *puts != 0 {
+		t.Errorf("made %d PUTs on conflict, want 0", *puts)
+	}
+}
+
+func TestPutCustomerMetaDataIfUnchanged_Conflict(t *testing.T) {
+	puts, _ := customerServer(t, "2026-10-18T10:03:00.000Z")
+
+	based := &CustomerFull{Customer: Customer{Id: "c1"}, UpdatedAt: "2026-10-18T10:00:00.000Z"}
+	_, err := PutCustomerMetaDataIfUnchanged(map[string]any{"tier": "gold"}, based, "t", "a", 0, nil)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	if *puts != 0 {
+		t.Errorf("made %d PUTs on conflict, want 0", *puts)
+	}
+}
+
+func TestUpdateProductIfUnchanged(t *testing.T) {
+	var puts int32
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"p1","updatedAt":"2026-10-18T10:00:00.000Z"}`))
+		case http.MethodPut:
+			atomic.AddInt32(&puts, 1)
+			w.Write([]byte(`{"id":"p1","updatedAt":"2026-10-18T10:05:00.000Z"}`))
+		}
+	}))
+
+	based := &C7Product{ID: "p1", UpdatedAt: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)}
+	if _, err := UpdateProductIfUnchanged(based, validProductPost(), "t", "a", 0, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puts != 1 {
+		t.Fatalf("made %d PUTs, want 1", puts)
+	}
+
+	stale := &C7Product{ID: "p1", UpdatedAt: based.UpdatedAt.Add(-time.Minute)}
+	if _, err := UpdateProductIfUnchanged(stale, validProductPost(), "t", "a", 0, nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("err = %v, want a conflict", err)
+	}
+	if puts != 1 {
+		t.Errorf("made %d PUTs, want no more after a conflict", puts)
+	}
+}
+
+func TestPutMetaDataConfigIfUnchanged(t *testing.T) {
+	var puts int32
+	var got MetaDataConfigPut
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/meta-data-config/customer/m1" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"m1","title":"Tier","updatedAt":"2026-10-18T10:00:00.000Z"}`))
+		case http.MethodPut:
+			atomic.AddInt32(&puts, 1)
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &got)
+			w.Write([]byte(`{"id":"m1","title":"Club Tier","updatedAt":"2026-10-18T10:05:00.000Z"}`))
+		}
+	}))
+
+	change := &MetaDataConfigPut{Title: "Club Tier", DataType: MetaDataTypeString}
+
+	stale := &MetaDataConfig{Id: "m1", UpdatedAt: "2026-10-18T09:00:00.000Z"}
+	if _, err := PutMetaDataConfigIfUnchanged(change, MetaDataConfigObjectCustomer, stale, "t", "a", 0, nil); !errors.Is(err, ErrConflict) {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	if puts != 0 {
+		t.Fatalf("made %d PUTs on conflict, want 0", puts)
+	}
+
+	based := &MetaDataConfig{Id: "m1", UpdatedAt: "2026-10-18T10:00:00Z"}
+	updated, err := PutMetaDataConfigIfUnchanged(change, MetaDataConfigObjectCustomer, based, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puts != 1 || got.Title != "Club Tier" || updated.Title != "Club Tier" {
+		t.Errorf("puts = %d, sent %+v, updated %+v", puts, got, updated)
+	}
+}
+
+func TestUpdateIfUnchanged_RequiresVersion(t *testing.T) {
+	read := func() (*C7Product, error) {
+		t.Error("read should not be called without a version")
+		return nil, nil
+	}
+	_, err := UpdateIfUnchanged("product", "p1", time.Time{}, read,
+		func(p *C7Product) (time.Time, error) { return p.UpdatedAt, nil },
+		func(*C7Product) (*C7Product, error) { return nil, nil })
+	if err == nil {
+		t.Error("expected an error for a zero basedOn")
+	}
+}
@@ -16,6 +16,7 @@ type endpoints struct {
 	GiftCardTransaction  string // "https://api.commerce7.com/v1/gift-card-transaction"
 	Inventory            string // "https://api.commerce7.com/v1/inventory" - https://developer.commerce7.com/docs/inventory
 	InventoryTransaction string // "https://api.commerce7.com/v1/inventory-transaction" - https://developer.commerce7.com/docs/inventory
+	InventoryLocation    string // "https://api.commerce7.com/v1/inventory-location" - https://developer.commerce7.com/docs/inventory
 	MetaDataConfig       string // "https://api.commerce7.com/v1/meta-data-config/" + "{:obj}" - Requires an object appended; allocation, club-membership, collection, customer, customer-address, order, product, reservation, experience
 	// "https://api.commerce7.com/v1/meta-data-config/{:obj}" - Requires an object appended; allocation, club-membership, collection, customer, customer-address, order, product, reservation, experience
 	//
@@ -49,6 +50,7 @@ func GetEndpoints(baseURL string) *endpoints {
 		GiftCardTransaction:           baseURL + "/gift-card-transaction",
 		Inventory:                     baseURL + "/inventory",
 		InventoryTransaction:          baseURL + "/inventory-transaction",
+		InventoryLocation:             baseURL + "/inventory-location",
 		MetaDataConfig:                baseURL + "/meta-data-config/",
 		MetaDataConfigObj:             baseURL + "/meta-data-config/{:obj}",
 		MetaDataConfigObjIsUniqueCode: baseURL + "/meta-data-config/{:obj}/is-unique-code",
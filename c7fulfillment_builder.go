@@ -0,0 +1,264 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FulfillmentBuilder plans one or more fulfillments against an order, such
+// as a split shipment under two tracking numbers, or part shipped now and the
+// rest picked up later.
+//
+//	b := c7api.NewFulfillmentBuilder(order)
+//	b.Ship("UPS", "1Z999AA10123456784").ItemBySku("CAB21-750", 6).Packages(1)
+//	b.Ship("FedEx", "794698123456").AllRemaining()
+//	updated, err := b.Create(tenant, auth, 3, rl)
+//
+// Ship and PickUp each start a new fulfillment, and the item methods add to
+// the one most recently started. What is left to fulfill comes from
+// Items[].QuantityFulfilled on the order, so pass a current copy.
+type FulfillmentBuilder struct {
+	order        *C7Order
+	fulfillments []FulfillmentPost
+	err          error
+}
+
+func NewFulfillmentBuilder(order *C7Order) *FulfillmentBuilder {
+	return &FulfillmentBuilder{order: order}
+}
+
+// Ship starts a shipped fulfillment with the given carrier and tracking
+// numbers. The carrier may be any name or alias in the carrier registry; it
+// is sent under its canonical name.
+func (b *FulfillmentBuilder) Ship(carrier string, trackingNumbers ...string) *FulfillmentBuilder {
+	b.fulfillments = append(b.fulfillments, FulfillmentPost{
+		Type:    OrderFulfillmentTypeShipped,
+		Shipped: &FulfillmentShipped{Carrier: carrier, TrackingNumbers: trackingNumbers},
+		Items:   []FulfillmentItemPost{},
+	})
+	return b
+}
+
+// PickUp starts a picked up fulfillment, for Pickup and Carry Out orders.
+func (b *FulfillmentBuilder) PickUp() *FulfillmentBuilder {
+	b.fulfillments = append(b.fulfillments, FulfillmentPost{
+		Type:  OrderFulfillmentTypePickedUp,
+		Items: []FulfillmentItemPost{},
+	})
+	return b
+}
+
+// Item adds quantity of an order line to the current fulfillment.
+func (b *FulfillmentBuilder) Item(orderItemId string, quantity int) *FulfillmentBuilder {
+	current := b.current()
+	if current == nil {
+		return b
+	}
+	current.Items = append(current.Items, FulfillmentItemPost{OrderItemID: orderItemId, Quantity: quantity})
+	return b
+}
+
+// ItemBySku adds quantity of the order line for sku to the current
+// fulfillment.
+func (b *FulfillmentBuilder) ItemBySku(sku string, quantity int) *FulfillmentBuilder {
+	for _, item := range b.order.Items {
+		if strings.EqualFold(item.Sku, sku) {
+			return b.Item(item.ID, quantity)
+		}
+	}
+	if b.err == nil {
+		b.err = fmt.Errorf("no item with sku %s on order %d", sku, b.order.OrderNumber)
+	}
+	return b
+}
+
+// AllRemaining adds every unit not yet fulfilled, and not already planned in
+// an earlier fulfillment, to the current one.
+func (b *FulfillmentBuilder) AllRemaining() *FulfillmentBuilder {
+	current := b.current()
+	if current == nil {
+		return b
+	}
+	planned := b.planned()
+	for _, item := range b.order.Items {
+		if !item.HasShipping && current.Type == OrderFulfillmentTypeShipped {
+			continue
+		}
+		if left := b.remaining(item.ID) - planned[item.ID]; left > 0 {
+			current.Items = append(current.Items, FulfillmentItemPost{OrderItemID: item.ID, Quantity: left})
+		}
+	}
+	return b
+}
+
+// Packages sets the package count on the current fulfillment.
+func (b *FulfillmentBuilder) Packages(count int) *FulfillmentBuilder {
+	if current := b.current(); current != nil {
+		current.PackageCount = count
+	}
+	return b
+}
+
+// At sets when the current fulfillment happened. It defaults to the time
+// Create is called.
+func (b *FulfillmentBuilder) At(fulfillmentDate time.Time) *FulfillmentBuilder {
+	if current := b.current(); current != nil {
+		current.FulfillmentDate = fulfillmentDate
+	}
+	return b
+}
+
+// From sets the inventory location the current fulfillment ships or is
+// picked up from.
+func (b *FulfillmentBuilder) From(inventoryLocationId string) *FulfillmentBuilder {
+	if current := b.current(); current != nil {
+		current.InventoryLocationID = inventoryLocationId
+	}
+	return b
+}
+
+// SendEmail sets whether Commerce7 emails the customer about the current
+// fulfillment.
+func (b *FulfillmentBuilder) SendEmail(send bool) *FulfillmentBuilder {
+	if current := b.current(); current != nil {
+		current.SendTransactionEmail = send
+	}
+	return b
+}
+
+// Fulfillments returns the planned fulfillments, in the order they will be
+// posted.
+func (b *FulfillmentBuilder) Fulfillments() []FulfillmentPost {
+	return append([]FulfillmentPost(nil), b.fulfillments...)
+}
+
+// Validate checks the plan against the order without sending anything.
+func (b *FulfillmentBuilder) Validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.order == nil || b.order.ID == "" {
+		return errors.New("fulfillment requires an order")
+	}
+	if len(b.fulfillments) == 0 {
+		return errors.New("no fulfillments planned")
+	}
+
+	lines := map[string]bool{}
+	for _, item := range b.order.Items {
+		lines[item.ID] = true
+	}
+
+	requested := map[string]int{}
+	for i, f := range b.fulfillments {
+		switch f.Type {
+		case OrderFulfillmentTypeShipped:
+			if f.Shipped == nil || f.Shipped.Carrier == "" {
+				return fmt.Errorf("fulfillment %d: shipped fulfillments require a carrier", i)
+			}
+			if len(f.Shipped.TrackingNumbers) == 0 {
+				return fmt.Errorf("fulfillment %d: shipped fulfillments require a tracking number", i)
+			}
+			if _, _, err := ValidateTracking(f.Shipped.Carrier, f.Shipped.TrackingNumbers); err != nil {
+				return fmt.Errorf("fulfillment %d: %w", i, err)
+			}
+		case OrderFulfillmentTypePickedUp:
+			if b.order.OrderDeliveryMethod == OrderDeliveryMethodShip {
+				return fmt.Errorf("fulfillment %d: order %d ships, it cannot be picked up", i, b.order.OrderNumber)
+			}
+		}
+
+		if len(f.Items) == 0 {
+			return fmt.Errorf("fulfillment %d: no items", i)
+		}
+		if f.PackageCount < 0 {
+			return fmt.Errorf("fulfillment %d: package count cannot be negative", i)
+		}
+		for _, item := range f.Items {
+			if !lines[item.OrderItemID] {
+				return fmt.Errorf("fulfillment %d: item %s is not on order %d", i, item.OrderItemID, b.order.OrderNumber)
+			}
+			if item.Quantity <= 0 {
+				return fmt.Errorf("fulfillment %d: item %s quantity must be positive", i, item.OrderItemID)
+			}
+			requested[item.OrderItemID] += item.Quantity
+		}
+	}
+
+	for id, quantity := range requested {
+		if remaining := b.remaining(id); quantity > remaining {
+			return fmt.Errorf("item %s: %d requested but only %d left to fulfill", id, quantity, remaining)
+		}
+	}
+	return nil
+}
+
+// Create validates the plan and posts each fulfillment in turn, returning the
+// order as it stands after the last one. If a post fails, the fulfillments
+// before it have already been made; the error says how many.
+func (b *FulfillmentBuilder) Create(tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	url := Endpoints.Order + "/" + b.order.ID + "/fulfillment"
+
+	var updated *C7Order
+	for i, f := range b.fulfillments {
+		if f.FulfillmentDate.IsZero() {
+			f.FulfillmentDate = now
+		}
+		if f.Shipped != nil {
+			// Already validated, so this only canonicalizes.
+			carrier, tracking, _ := ValidateTracking(f.Shipped.Carrier, f.Shipped.TrackingNumbers)
+			f.Shipped = &FulfillmentShipped{Carrier: carrier, TrackingNumbers: tracking}
+		}
+
+		resp, err := Post(&f, url, tenant, c7AppAuthEncoded, retryCount, rl)
+		if err != nil {
+			return updated, fmt.Errorf("failed to post fulfillment %d of %d: %w", i+1, len(b.fulfillments), err)
+		}
+
+		var order C7Order
+		if err := json.Unmarshal(*resp, &order); err != nil {
+			return updated, fmt.Errorf("failed to unmarshal order after fulfillment %d of %d: %w", i+1, len(b.fulfillments), err)
+		}
+		updated = &order
+	}
+	return updated, nil
+}
+
+func (b *FulfillmentBuilder) current() *FulfillmentPost {
+	if len(b.fulfillments) == 0 {
+		if b.err == nil {
+			b.err = errors.New("call Ship or PickUp before adding to a fulfillment")
+		}
+		return nil
+	}
+	return &b.fulfillments[len(b.fulfillments)-1]
+}
+
+// remaining is how much of an order line has not been fulfilled on the order.
+func (b *FulfillmentBuilder) remaining(orderItemId string) int {
+	for _, item := range b.order.Items {
+		if item.ID == orderItemId {
+			return item.Quantity - item.QuantityFulfilled
+		}
+	}
+	return 0
+}
+
+// planned totals the quantities already added to fulfillments.
+func (b *FulfillmentBuilder) planned() map[string]int {
+	planned := map[string]int{}
+	for _, f := range b.fulfillments {
+		for _, item := range f.Items {
+			planned[item.OrderItemID] += item.Quantity
+		}
+	}
+	return planned
+}
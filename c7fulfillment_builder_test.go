@@ -0,0 +1,104 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func fulfillmentTestOrder(t *testing.T, deliveryMethod string) *C7Order {
+	t.Helper()
+	var order C7Order
+	err := json.Unmarshal([]byte(`{"id":"o1","orderNumber":1001,"orderDeliveryMethod":"`+deliveryMethod+`","items":[
+		{"id":"i1","sku":"CAB21-750","quantity":12,"quantityFulfilled":2,"hasShipping":true},
+		{"id":"i2","sku":"CHARD22-750","quantity":3,"quantityFulfilled":0,"hasShipping":true}
+	]}`), &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &order
+}
+
+func TestFulfillmentBuilder_Validate(t *testing.T) {
+	order := fulfillmentTestOrder(t, OrderDeliveryMethodShip)
+
+	tests := []struct {
+		name    string
+		build   func(b *FulfillmentBuilder)
+		wantErr bool
+	}{
+		{"split shipment", func(b *FulfillmentBuilder) {
+			b.Ship("UPS", "1Z999AA10123456784").ItemBySku("CAB21-750", 6)
+			b.Ship("FedEx", "794698123456", "794698123457").AllRemaining()
+		}, false},
+		{"more than remains", func(b *FulfillmentBuilder) {
+			b.Ship("UPS", "1Z999AA10123456784").Item("i1", 6)
+			b.Ship("UPS", "1Z999AA10123456785").Item("i1", 5)
+		}, true},
+		{"no tracking number", func(b *FulfillmentBuilder) { b.Ship("UPS").Item("i2", 1) }, true},
+		{"no carrier", func(b *FulfillmentBuilder) { b.Ship("", "1Z999AA10123456784").Item("i2", 1) }, true},
+		{"unsupported carrier", func(b *FulfillmentBuilder) { b.Ship("DHL", "1234567890").Item("i2", 1) }, true},
+		{"malformed tracking", func(b *FulfillmentBuilder) { b.Ship("FedEx", "1Z999AA10123456784").Item("i2", 1) }, true},
+		{"unknown item", func(b *FulfillmentBuilder) { b.Ship("UPS", "1Z999AA10123456784").Item("nope", 1) }, true},
+		{"unknown sku", func(b *FulfillmentBuilder) { b.Ship("UPS", "1Z999AA10123456784").ItemBySku("NOPE", 1) }, true},
+		{"item before shipment", func(b *FulfillmentBuilder) { b.Item("i1", 1).Ship("UPS", "1Z999AA10123456784").Item("i1", 1) }, true},
+		{"picked up on a ship order", func(b *FulfillmentBuilder) { b.PickUp().Item("i1", 1) }, true},
+		{"empty fulfillment", func(b *FulfillmentBuilder) { b.Ship("UPS", "1Z999AA10123456784") }, true},
+		{"nothing planned", func(b *FulfillmentBuilder) {}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewFulfillmentBuilder(order)
+			tc.build(b)
+			err := b.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFulfillmentBuilder_Create(t *testing.T) {
+	var posted []FulfillmentPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/order/o1/fulfillment" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var f FulfillmentPost
+		json.Unmarshal(body, &f)
+		posted = append(posted, f)
+		w.Write([]byte(`{"id":"o1","orderNumber":1001,"fulfillmentStatus":"Partially Fulfilled"}`))
+	}))
+
+	b := NewFulfillmentBuilder(fulfillmentTestOrder(t, OrderDeliveryMethodPickup))
+	b.Ship("ups", "1z999aa1 0123456784").Item("i1", 4).Packages(1)
+	b.PickUp().AllRemaining()
+
+	order, err := b.Create("t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.FulfillmentStatus != OrderFulfillmentStatusPartiallyFulfilled {
+		t.Errorf("order = %+v", order)
+	}
+	if len(posted) != 2 {
+		t.Fatalf("posted %d fulfillments, want 2", len(posted))
+	}
+	if posted[0].Shipped == nil || posted[0].Shipped.Carrier != "UPS" || posted[0].Shipped.TrackingNumbers[0] != "1Z999AA10123456784" || posted[0].FulfillmentDate.IsZero() {
+		t.Errorf("first fulfillment = %+v", posted[0])
+	}
+	pickup := posted[1]
+	if pickup.Type != OrderFulfillmentTypePickedUp || pickup.Shipped != nil {
+		t.Errorf("second fulfillment = %+v", pickup)
+	}
+	want := map[string]int{"i1": 6, "i2": 3}
+	for _, item := range pickup.Items {
+		if want[item.OrderItemID] != item.Quantity {
+			t.Errorf("picked up %d of %s, want %d", item.Quantity, item.OrderItemID, want[item.OrderItemID])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package c7api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Outcomes for EnsureShipped.
+const (
+	EnsureShippedUnchanged = "Unchanged" // Already shipped with the same carrier and tracking
+	EnsureShippedCreated   = "Created"   // Had no shipped fulfillment, one was added
+	EnsureShippedReplaced  = "Replaced"  // Shipped with different details, replaced
+)
+
+// EnsureShippedResult reports what EnsureShipped found and did.
+type EnsureShippedResult struct {
+	OrderID                 string
+	OrderNumber             int
+	Status                  string   // See EnsureShipped constants
+	PreviousCarrier         string   // Carrier on the shipped fulfillments found, if any
+	PreviousTrackingNumbers []string // Tracking numbers on the shipped fulfillments found
+	RemovedFulfillmentIDs   []string
+	Changes                 []string // Human readable, e.g. "carrier: UPS -> FedEx"
+}
+
+// EnsureShipped makes orderNumber shipped with exactly carrier and
+// trackingNumbers, and is safe to call repeatedly with the same tracking, as
+// a 3PL feed tends to.
+//
+// Existing Shipped fulfillments are compared as a whole: if together they
+// carry the same carrier and the same set of tracking numbers (ignoring case,
+// spacing and order), nothing is sent. Otherwise they are deleted and a single
+// fulfillment for all items is created in their place. Pickup and No
+// Fulfillment Required fulfillments are left alone.
+//
+// The carrier and tracking numbers are checked against the carrier registry
+// before anything is read. If replacing fails part way, the result still
+// lists what was removed.
+func EnsureShipped(orderNumber int, carrier string, trackingNumbers []string, shipTime time.Time, tenant string, auth string, attempts int, rl genericRateLimiter) (*EnsureShippedResult, error) {
+	if len(trackingNumbers) == 0 {
+		return nil, errors.New("no tracking numbers provided")
+	}
+	carrier, trackingNumbers, err := ValidateTracking(carrier, trackingNumbers)
+	if err != nil {
+		return nil, err
+	}
+	desired := normalizeTrackingNumbers(trackingNumbers)
+
+	order, err := getOrderByNumber(orderNumber, tenant, auth, attempts, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %d: %w", orderNumber, err)
+	}
+
+	result := &EnsureShippedResult{OrderID: order.ID, OrderNumber: order.OrderNumber}
+
+	var shipped []C7OrderFulfillment
+	var existing []string
+	for _, f := range order.Fulfillments {
+		if f.Type != OrderFulfillmentTypeShipped {
+			continue
+		}
+		shipped = append(shipped, f)
+		existing = append(existing, f.Shipped.TrackingNumbers...)
+		if result.PreviousCarrier == "" {
+			result.PreviousCarrier = f.Shipped.Carrier
+		} else if !strings.EqualFold(result.PreviousCarrier, f.Shipped.Carrier) {
+			// Mixed carriers can never match a single desired carrier.
+			result.PreviousCarrier += ", " + f.Shipped.Carrier
+		}
+	}
+	result.PreviousTrackingNumbers = normalizeTrackingNumbers(existing)
+
+	if len(shipped) > 0 && sameCarrier(result.PreviousCarrier, carrier) && slices.Equal(result.PreviousTrackingNumbers, desired) {
+		result.Status = EnsureShippedUnchanged
+		return result, nil
+	}
+
+	if len(shipped) == 0 {
+		result.Status = EnsureShippedCreated
+		result.Changes = append(result.Changes, fmt.Sprintf("shipped: %s %s", carrier, strings.Join(desired, ", ")))
+	} else {
+		result.Status = EnsureShippedReplaced
+		if !sameCarrier(result.PreviousCarrier, carrier) {
+			result.Changes = append(result.Changes, fmt.Sprintf("carrier: %s -> %s", result.PreviousCarrier, carrier))
+		}
+		if !slices.Equal(result.PreviousTrackingNumbers, desired) {
+			result.Changes = append(result.Changes, fmt.Sprintf("tracking: %s -> %s", strings.Join(result.PreviousTrackingNumbers, ", "), strings.Join(desired, ", ")))
+		}
+	}
+
+	for _, f := range shipped {
+		if _, err := DeleteFulfillmentById(order.ID, f.ID, tenant, auth, attempts, rl); err != nil {
+			return result, fmt.Errorf("failed to delete fulfillment %s: %w", f.ID, err)
+		}
+		result.RemovedFulfillmentIDs = append(result.RemovedFulfillmentIDs, f.ID)
+	}
+
+	fulfillment := FulfillmentAllItems{
+		Type:            OrderFulfillmentTypeShipped,
+		FulfillmentDate: shipTime,
+		Shipped:         &FulfillmentShipped{TrackingNumbers: desired, Carrier: carrier},
+		PackageCount:    len(desired),
+	}
+
+	url := Endpoints.Order + "/" + order.ID + "/fulfillment/all"
+	if _, err := Post(&fulfillment, url, tenant, auth, attempts, rl); err != nil {
+		return result, fmt.Errorf("failed to post shipped fulfillment: %w", err)
+	}
+	return result, nil
+}
+
+// normalizeTrackingNumbers normalizes, de-duplicates and sorts tracking
+// numbers so two lists can be compared as sets.
+func normalizeTrackingNumbers(trackingNumbers []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tracking := range trackingNumbers {
+		tracking = NormalizeTrackingNumber(tracking)
+		if tracking == "" || seen[tracking] {
+			continue
+		}
+		seen[tracking] = true
+		normalized = append(normalized, tracking)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// sameCarrier compares carriers by canonical name, falling back to the names
+// as given for carriers outside the registry.
+func sameCarrier(a string, b string) bool {
+	if ca, ok := LookupCarrier(a); ok {
+		a = ca.Name
+	}
+	if cb, ok := LookupCarrier(b); ok {
+		b = cb.Name
+	}
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,75 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEnsureShipped(t *testing.T) {
+	const orders = `{"orders":[{"id":"o1","orderNumber":1001,"fulfillments":[
+		{"id":"f1","type":"Shipped","shipped":{"carrier":"UPS","trackingNumbers":["1z999aa10123456784"]}},
+		{"id":"f2","type":"Shipped","shipped":{"carrier":"UPS","trackingNumbers":["1Z999AA10123456785"]}},
+		{"id":"f3","type":"No Fulfillment Required"}
+	]},{"id":"o2","orderNumber":1002,"fulfillments":[]}],"total":2}`
+
+	var calls []string
+	var posted FulfillmentAllItems
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &posted)
+		}
+		w.Write([]byte(orders))
+	}))
+
+	tests := []struct {
+		name        string
+		orderNumber int
+		carrier     string
+		tracking    []string
+		wantStatus  string
+		wantCalls   []string
+		wantChanges int
+	}{
+		{"same tracking in another order and case", 1001, "United Parcel Service", []string{" 1Z999AA10123456785", "1Z999AA10123456784", "1z999aa10123456784"}, EnsureShippedUnchanged, nil, 0},
+		{"tracking changed", 1001, "UPS", []string{"1Z999AA10123456786"}, EnsureShippedReplaced,
+			[]string{"DELETE /order/o1/fulfillment/f1", "DELETE /order/o1/fulfillment/f2", "POST /order/o1/fulfillment/all"}, 1},
+		{"carrier and tracking changed", 1001, "FedEx", []string{"794698123456"}, EnsureShippedReplaced,
+			[]string{"DELETE /order/o1/fulfillment/f1", "DELETE /order/o1/fulfillment/f2", "POST /order/o1/fulfillment/all"}, 2},
+		{"not yet shipped", 1002, "UPS", []string{"1Z999AA10123456787"}, EnsureShippedCreated, []string{"POST /order/o2/fulfillment/all"}, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			result, err := EnsureShipped(tc.orderNumber, tc.carrier, tc.tracking, time.Now(), "t", "a", 0, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Status != tc.wantStatus {
+				t.Errorf("status = %s, want %s", result.Status, tc.wantStatus)
+			}
+			if len(result.Changes) != tc.wantChanges {
+				t.Errorf("changes = %v, want %d", result.Changes, tc.wantChanges)
+			}
+			if len(calls) != len(tc.wantCalls) {
+				t.Fatalf("calls = %v, want %v", calls, tc.wantCalls)
+			}
+			for i := range calls {
+				if calls[i] != tc.wantCalls[i] {
+					t.Errorf("call %d = %s, want %s", i, calls[i], tc.wantCalls[i])
+				}
+			}
+		})
+	}
+
+	if posted.Shipped == nil || posted.Shipped.Carrier != "UPS" || posted.Type != OrderFulfillmentTypeShipped {
+		t.Errorf("posted = %+v", posted)
+	}
+}
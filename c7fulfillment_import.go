@@ -0,0 +1,344 @@
+package c7api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FulfillmentImportColumns names the header columns of a carrier CSV. Names
+// are matched ignoring case and surrounding space. Carrier and ShipDate are
+// optional; leave them blank if the file has no such column.
+type FulfillmentImportColumns struct {
+	OrderNumber    string
+	Carrier        string
+	TrackingNumber string
+	ShipDate       string
+}
+
+// DefaultFulfillmentImportColumns matches a file with the headers
+// "Order Number", "Carrier", "Tracking Number" and "Ship Date".
+var DefaultFulfillmentImportColumns = FulfillmentImportColumns{
+	OrderNumber:    "Order Number",
+	Carrier:        "Carrier",
+	TrackingNumber: "Tracking Number",
+	ShipDate:       "Ship Date",
+}
+
+type FulfillmentImportOptions struct {
+	Columns              FulfillmentImportColumns // Defaults to DefaultFulfillmentImportColumns
+	DefaultCarrier       string                   // Used when a row has no carrier
+	ShipDateLayout       string                   // Defaults to "2006-01-02"
+	Location             *time.Location           // For ship dates without a zone; defaults to UTC
+	SendTransactionEmail bool
+	DryRun               bool // Check every row against Commerce7 without fulfilling anything
+}
+
+// FulfillmentImportRow is one line of a carrier CSV, as read.
+type FulfillmentImportRow struct {
+	Line           int
+	OrderNumber    string
+	Carrier        string
+	TrackingNumber string
+	ShipDate       string
+}
+
+// FulfillmentImportResult is the outcome for one row.
+type FulfillmentImportResult struct {
+	FulfillmentImportRow
+	Status  string // BulkStatusApplied, BulkStatusSkipped or BulkStatusFailed
+	Message string
+}
+
+type FulfillmentImportSummary struct {
+	Applied int
+	Skipped int
+	Failed  int
+	Results []FulfillmentImportResult // In file order
+}
+
+// ReadFulfillmentImportCSV reads the rows of a carrier CSV. Only a missing
+// column fails the whole file; bad values are reported per row on import.
+func ReadFulfillmentImportCSV(r io.Reader, columns FulfillmentImportColumns) ([]FulfillmentImportRow, error) {
+	if columns == (FulfillmentImportColumns{}) {
+		columns = DefaultFulfillmentImportColumns
+	}
+	if columns.OrderNumber == "" || columns.TrackingNumber == "" {
+		return nil, errors.New("order number and tracking number columns are required")
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("while reading fulfillment csv header: %w", err)
+	}
+
+	find := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("fulfillment csv has no %q column", name)
+	}
+
+	orderCol, err := find(columns.OrderNumber)
+	if err != nil {
+		return nil, err
+	}
+	carrierCol, err := find(columns.Carrier)
+	if err != nil {
+		return nil, err
+	}
+	trackingCol, err := find(columns.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	dateCol, err := find(columns.ShipDate)
+	if err != nil {
+		return nil, err
+	}
+
+	field := func(record []string, col int) string {
+		if col < 0 || col >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[col])
+	}
+
+	rows := []FulfillmentImportRow{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while reading fulfillment csv line %d: %w", line, err)
+		}
+
+		row := FulfillmentImportRow{
+			Line:           line,
+			OrderNumber:    field(record, orderCol),
+			Carrier:        field(record, carrierCol),
+			TrackingNumber: field(record, trackingCol),
+			ShipDate:       field(record, dateCol),
+		}
+		// Spreadsheets leave trailing blank lines behind.
+		if row == (FulfillmentImportRow{Line: line}) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// fulfillmentImportGroup is the rows for one order, which become a single
+// shipped fulfillment with every tracking number on them.
+type fulfillmentImportGroup struct {
+	orderNumber int
+	carrier     string
+	tracking    []string
+	shipDate    time.Time
+	indexes     []int
+	err         error
+}
+
+// ImportFulfillments ships each order in rows, writing a results CSV to out
+// with one line per row.
+//
+// Rows for the same order are combined into one fulfillment carrying all
+// their tracking numbers, so a multi-package shipment can be listed a line
+// per box. Carriers are resolved through the carrier registry and tracking
+// numbers checked against it; if any row of an order fails those checks, the
+// whole order is reported failed rather than shipped short. Orders that are
+// already fulfilled are skipped, which makes it safe to import the same file
+// twice. Partially fulfilled orders have their remaining items shipped.
+func ImportFulfillments(rows []FulfillmentImportRow, opts FulfillmentImportOptions, out io.Writer, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*FulfillmentImportSummary, error) {
+	layout := opts.ShipDateLayout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	summary := &FulfillmentImportSummary{Results: make([]FulfillmentImportResult, len(rows))}
+	groups := []*fulfillmentImportGroup{}
+	byOrder := map[int]*fulfillmentImportGroup{}
+
+	for i, row := range rows {
+		summary.Results[i] = FulfillmentImportResult{FulfillmentImportRow: row}
+		result := &summary.Results[i]
+
+		orderNumber, err := strconv.Atoi(strings.TrimPrefix(row.OrderNumber, "#"))
+		if err != nil || orderNumber <= 0 {
+			result.Status, result.Message = BulkStatusFailed, fmt.Sprintf("invalid order number: %q", row.OrderNumber)
+			continue
+		}
+
+		group, ok := byOrder[orderNumber]
+		if !ok {
+			group = &fulfillmentImportGroup{orderNumber: orderNumber}
+			byOrder[orderNumber] = group
+			groups = append(groups, group)
+		}
+		group.indexes = append(group.indexes, i)
+
+		// Shipping the other rows without this one's tracking numbers would
+		// leave the order looking complete, so a bad row fails its order.
+		fail := func(err error) {
+			if group.err == nil {
+				group.err = fmt.Errorf("line %d: %w", row.Line, err)
+			}
+		}
+
+		carrierName := row.Carrier
+		if carrierName == "" {
+			carrierName = opts.DefaultCarrier
+		}
+		carrier, tracking, err := ValidateTracking(carrierName, strings.FieldsFunc(row.TrackingNumber, isTrackingSeparator))
+		if err == nil && len(tracking) == 0 {
+			err = errors.New("no tracking number")
+		}
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		var shipDate time.Time
+		if row.ShipDate != "" {
+			shipDate, err = time.ParseInLocation(layout, row.ShipDate, loc)
+			if err != nil {
+				fail(fmt.Errorf("invalid ship date: %q", row.ShipDate))
+				continue
+			}
+		}
+
+		group.tracking = append(group.tracking, tracking...)
+		if group.carrier == "" {
+			group.carrier = carrier
+		} else if group.carrier != carrier {
+			fail(fmt.Errorf("order %d is listed with both %s and %s", orderNumber, group.carrier, carrier))
+		}
+		if shipDate.After(group.shipDate) {
+			group.shipDate = shipDate
+		}
+	}
+
+	now := time.Now()
+	for _, group := range groups {
+		status, message := importFulfillmentGroup(group, opts, now, tenant, c7AppAuthEncoded, retryCount, rl)
+		for _, i := range group.indexes {
+			summary.Results[i].Status, summary.Results[i].Message = status, message
+		}
+	}
+
+	for _, result := range summary.Results {
+		switch result.Status {
+		case BulkStatusApplied:
+			summary.Applied++
+		case BulkStatusSkipped:
+			summary.Skipped++
+		default:
+			summary.Failed++
+		}
+	}
+
+	if err := writeFulfillmentImportResults(out, summary.Results); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+func importFulfillmentGroup(group *fulfillmentImportGroup, opts FulfillmentImportOptions, now time.Time, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (string, string) {
+	if group.err != nil {
+		return BulkStatusFailed, group.err.Error()
+	}
+
+	order, err := getOrderByNumber(group.orderNumber, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return BulkStatusFailed, fmt.Sprintf("failed to find order: %v", err)
+	}
+
+	switch order.FulfillmentStatus {
+	case OrderFulfillmentStatusFulfilled, OrderFulfillmentStatusNoFulfillmentRequired:
+		return BulkStatusSkipped, "order is already " + strings.ToLower(order.FulfillmentStatus)
+	}
+
+	shipDate := group.shipDate
+	if shipDate.IsZero() {
+		shipDate = now
+	}
+
+	tracking := normalizeTrackingNumbers(group.tracking)
+	builder := NewFulfillmentBuilder(order).
+		Ship(group.carrier, tracking...).
+		AllRemaining().
+		Packages(len(tracking)).
+		At(shipDate).
+		SendEmail(opts.SendTransactionEmail)
+	if err := builder.Validate(); err != nil {
+		return BulkStatusFailed, err.Error()
+	}
+
+	if opts.DryRun {
+		return BulkStatusSkipped, "dry run"
+	}
+	if _, err := builder.Create(tenant, c7AppAuthEncoded, retryCount, rl); err != nil {
+		return BulkStatusFailed, err.Error()
+	}
+	return BulkStatusApplied, fmt.Sprintf("shipped %s %s", group.carrier, strings.Join(tracking, ", "))
+}
+
+func writeFulfillmentImportResults(out io.Writer, results []FulfillmentImportResult) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"Line", "Order Number", "Carrier", "Tracking Number", "Status", "Message"})
+	for _, r := range results {
+		writer.Write([]string{strconv.Itoa(r.Line), r.OrderNumber, r.Carrier, r.TrackingNumber, r.Status, r.Message})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("while writing fulfillment import results: %w", err)
+	}
+	return nil
+}
+
+// ImportFulfillmentsFromCSVFile reads a carrier CSV from inputPath and writes
+// the results CSV to outputPath, replacing any file already there.
+func ImportFulfillmentsFromCSVFile(inputPath string, outputPath string, opts FulfillmentImportOptions, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*FulfillmentImportSummary, error) {
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	rows, err := ReadFulfillmentImportCSV(input, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := os.Create(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Close()
+
+	return ImportFulfillments(rows, opts, output, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// isTrackingSeparator splits a cell holding several tracking numbers.
+func isTrackingSeparator(r rune) bool {
+	return r == ',' || r == ';' || r == '|' || r == '\n'
+}
@@ -0,0 +1,132 @@
+package c7api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestImportFulfillments(t *testing.T) {
+	const input = `Ref,Ship Via,Tracking,Shipped
+1001,UPS,1Z999AA10123456784,2024-03-01
+#1001,ups,1Z999AA10123456785,2024-03-02
+1002,FedEx,794698123456,2024-03-01
+1003,FedEx,1Z999AA10123456784,2024-03-01
+abc,UPS,1Z999AA10123456784,2024-03-01
+1004,,794698123457,03/01/2024
+,,,
+`
+	orders := map[string]string{
+		"1001": `{"id":"o1","orderNumber":1001,"fulfillmentStatus":"Not Fulfilled","orderDeliveryMethod":"Ship","items":[{"id":"i1","quantity":6,"hasShipping":true}]}`,
+		"1002": `{"id":"o2","orderNumber":1002,"fulfillmentStatus":"Fulfilled","orderDeliveryMethod":"Ship","items":[{"id":"i1","quantity":6,"quantityFulfilled":6,"hasShipping":true}]}`,
+		"1004": `{"id":"o4","orderNumber":1004,"fulfillmentStatus":"Not Fulfilled","orderDeliveryMethod":"Ship","items":[{"id":"i1","quantity":1,"hasShipping":true}]}`,
+	}
+
+	var posted []FulfillmentPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			var f FulfillmentPost
+			json.Unmarshal(body, &f)
+			posted = append(posted, f)
+			w.Write([]byte(`{"id":"o1"}`))
+			return
+		}
+		order, ok := orders[r.URL.Query().Get("q")]
+		if !ok {
+			w.Write([]byte(`{"orders":[],"total":0}`))
+			return
+		}
+		w.Write([]byte(`{"orders":[` + order + `],"total":1}`))
+	}))
+
+	columns := FulfillmentImportColumns{OrderNumber: "ref", Carrier: "ship via", TrackingNumber: "tracking", ShipDate: "shipped"}
+	rows, err := ReadFulfillmentImportCSV(strings.NewReader(input), columns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("read %d rows, want 6", len(rows))
+	}
+
+	var out bytes.Buffer
+	opts := FulfillmentImportOptions{DefaultCarrier: "FedEx", ShipDateLayout: "2006-01-02"}
+	summary, err := ImportFulfillments(rows, opts, &out, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{BulkStatusApplied, BulkStatusApplied, BulkStatusSkipped, BulkStatusFailed, BulkStatusFailed, BulkStatusFailed}
+	for i, result := range summary.Results {
+		if result.Status != want[i] {
+			t.Errorf("line %d: status = %s (%s), want %s", result.Line, result.Status, result.Message, want[i])
+		}
+	}
+	if summary.Applied != 2 || summary.Skipped != 1 || summary.Failed != 3 {
+		t.Errorf("summary = %d applied, %d skipped, %d failed", summary.Applied, summary.Skipped, summary.Failed)
+	}
+
+	// Both lines for 1001 become one fulfillment with the later ship date.
+	if len(posted) != 1 {
+		t.Fatalf("posted %d fulfillments, want 1", len(posted))
+	}
+	if got := posted[0].Shipped.TrackingNumbers; len(got) != 2 || posted[0].PackageCount != 2 {
+		t.Errorf("posted = %+v", posted[0])
+	}
+	if got := posted[0].FulfillmentDate.Format("2006-01-02"); got != "2024-03-02" {
+		t.Errorf("fulfillment date = %s, want 2024-03-02", got)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("results csv: %v", err)
+	}
+	if len(records) != 7 || records[0][4] != "Status" || records[3][4] != BulkStatusSkipped {
+		t.Errorf("results = %v", records)
+	}
+}
+
+// One bad row must fail its whole order, not ship the rest of it without
+// that row's tracking number.
+func TestImportFulfillments_InvalidRowFailsOrder(t *testing.T) {
+	var posts int
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		w.Write([]byte(`{"orders":[{"id":"o1","orderNumber":1001,"fulfillmentStatus":"Not Fulfilled","orderDeliveryMethod":"Ship","items":[{"id":"i1","quantity":6,"hasShipping":true}]}],"total":1}`))
+	}))
+
+	rows := []FulfillmentImportRow{
+		{Line: 2, OrderNumber: "1001", Carrier: "UPS", TrackingNumber: "1Z999AA10123456784"},
+		{Line: 3, OrderNumber: "1001", Carrier: "UPS", TrackingNumber: "not-a-tracking-number"},
+	}
+
+	var out bytes.Buffer
+	summary, err := ImportFulfillments(rows, FulfillmentImportOptions{}, &out, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if posts != 0 {
+		t.Errorf("posted %d fulfillments for an order with a bad row, want 0", posts)
+	}
+	if summary.Failed != 2 {
+		t.Errorf("summary = %d applied, %d skipped, %d failed, want both rows failed", summary.Applied, summary.Skipped, summary.Failed)
+	}
+	for _, result := range summary.Results {
+		if !strings.Contains(result.Message, "line 3") {
+			t.Errorf("line %d: message %q should point at line 3", result.Line, result.Message)
+		}
+	}
+}
+
+func TestReadFulfillmentImportCSV_MissingColumn(t *testing.T) {
+	_, err := ReadFulfillmentImportCSV(strings.NewReader("Order Number,Tracking Number\n1001,1Z1\n"), DefaultFulfillmentImportColumns)
+	if err == nil {
+		t.Error("expected an error for a missing carrier column")
+	}
+}
@@ -0,0 +1,206 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GetInventory returns every inventory level matching queries, walking all
+// pages. Commerce7 filters include "sku" and "inventoryLocationId".
+func GetInventory(queries map[string]string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]InventoryLevel, error) {
+	levels, err := GetAll[InventoryLevel, InventoryLevels](Endpoints.Inventory, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+	return levels, nil
+}
+
+// GetInventoryBySku returns the levels of sku at every location that stocks it.
+func GetInventoryBySku(sku string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]InventoryLevel, error) {
+	if sku == "" {
+		return nil, errors.New("no sku provided")
+	}
+	return GetInventory(map[string]string{"sku": sku}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// GetInventoryLevel returns the level of sku at a single location. A SKU that
+// has never been stocked there is reported as a zero level, not an error.
+func GetInventoryLevel(sku string, inventoryLocationId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryLevel, error) {
+	if inventoryLocationId == "" {
+		return nil, errors.New("no inventoryLocationId provided")
+	}
+
+	levels, err := GetInventoryBySku(sku, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	if level := findInventoryLevel(*levels, sku, inventoryLocationId); level != nil {
+		return level, nil
+	}
+	return &InventoryLevel{Sku: sku, InventoryLocationID: inventoryLocationId}, nil
+}
+
+// readBackInventoryLevel returns the level of sku at a location that has just
+// been written to. Unlike GetInventoryLevel, a missing level is an error: it
+// means the read is out of step with the write, not an empty location.
+func readBackInventoryLevel(sku string, inventoryLocationId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryLevel, error) {
+	levels, err := GetInventoryBySku(sku, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	level := findInventoryLevel(*levels, sku, inventoryLocationId)
+	if level == nil {
+		return nil, fmt.Errorf("wrote %s but could not read its level at %s back", sku, inventoryLocationId)
+	}
+	return level, nil
+}
+
+// findInventoryLevel picks the level of sku at a location out of a SKU
+// search, which can also match other SKUs.
+func findInventoryLevel(levels []InventoryLevel, sku string, inventoryLocationId string) *InventoryLevel {
+	for _, level := range levels {
+		if level.InventoryLocationID == inventoryLocationId && strings.EqualFold(level.Sku, sku) {
+			return &level
+		}
+	}
+	return nil
+}
+
+func GetInventoryLocations(tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]InventoryLocation, error) {
+	locations, err := GetAll[InventoryLocation, InventoryLocations](Endpoints.InventoryLocation, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory locations: %w", err)
+	}
+	return locations, nil
+}
+
+// PostInventoryTransaction validates and posts a single inventory movement.
+//
+// Most callers want ReceiveInventory, AdjustInventory or TransferInventory,
+// which fill in the transaction type and return the resulting levels.
+func PostInventoryTransaction(transaction *InventoryTransactionPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryTransaction, error) {
+	if err := validateInventoryTransaction(transaction); err != nil {
+		return nil, err
+	}
+
+	resp, err := Post(transaction, Endpoints.InventoryTransaction, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post inventory transaction: %w", err)
+	}
+
+	var created InventoryTransaction
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inventory transaction: %w", err)
+	}
+	return &created, nil
+}
+
+// ReceiveInventory adds quantity of sku to a location and returns the new
+// level there.
+func ReceiveInventory(sku string, inventoryLocationId string, quantity int, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryLevel, error) {
+	if quantity <= 0 {
+		return nil, errors.New("received quantity must be positive")
+	}
+
+	_, err := PostInventoryTransaction(&InventoryTransactionPost{
+		Sku:                 sku,
+		InventoryLocationID: inventoryLocationId,
+		TransactionType:     InventoryTransactionTypeReceive,
+		Reason:              InventoryReasonReceived,
+		Quantity:            quantity,
+		Notes:               notes,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	return readBackInventoryLevel(sku, inventoryLocationId, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// AdjustInventory changes the level of sku at a location by delta, which may
+// be negative, and returns the new level there.
+func AdjustInventory(sku string, inventoryLocationId string, delta int, reason string, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryLevel, error) {
+	if delta == 0 {
+		return nil, errors.New("adjustment cannot be zero")
+	}
+
+	_, err := PostInventoryTransaction(&InventoryTransactionPost{
+		Sku:                 sku,
+		InventoryLocationID: inventoryLocationId,
+		TransactionType:     InventoryTransactionTypeAdjust,
+		Reason:              reason,
+		Quantity:            delta,
+		Notes:               notes,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	return readBackInventoryLevel(sku, inventoryLocationId, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// TransferInventory moves quantity of sku between two locations and returns
+// the new levels at the source and destination, in that order.
+func TransferInventory(sku string, fromLocationId string, toLocationId string, quantity int, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryLevel, *InventoryLevel, error) {
+	if quantity <= 0 {
+		return nil, nil, errors.New("transfer quantity must be positive")
+	}
+
+	_, err := PostInventoryTransaction(&InventoryTransactionPost{
+		Sku:                   sku,
+		InventoryLocationID:   fromLocationId,
+		ToInventoryLocationID: toLocationId,
+		TransactionType:       InventoryTransactionTypeTransfer,
+		Reason:                InventoryReasonTransfer,
+		Quantity:              quantity,
+		Notes:                 notes,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	levels, err := GetInventoryBySku(sku, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Both locations have just been part of a transfer, so a missing level
+	// means the read is out of step with the write, not an empty location.
+	from := findInventoryLevel(*levels, sku, fromLocationId)
+	to := findInventoryLevel(*levels, sku, toLocationId)
+	if from == nil || to == nil {
+		return nil, nil, fmt.Errorf("transferred %s but could not read its levels at %s and %s back", sku, fromLocationId, toLocationId)
+	}
+	return from, to, nil
+}
+
+func validateInventoryTransaction(transaction *InventoryTransactionPost) error {
+	if transaction == nil {
+		return errors.New("inventory transaction cannot be nil")
+	}
+	if transaction.Sku == "" {
+		return errors.New("no sku provided")
+	}
+	if transaction.InventoryLocationID == "" {
+		return errors.New("no inventoryLocationId provided")
+	}
+	if !IsValidInventoryTransactionType(transaction.TransactionType) {
+		return fmt.Errorf("invalid inventory transaction type: %s", transaction.TransactionType)
+	}
+	if !IsValidInventoryReason(transaction.Reason) {
+		return fmt.Errorf("invalid inventory reason %q, must be one of: %s", transaction.Reason, strings.Join(GetValidInventoryReasons(), ", "))
+	}
+	if transaction.TransactionType == InventoryTransactionTypeTransfer {
+		if transaction.ToInventoryLocationID == "" {
+			return errors.New("transfer requires a toInventoryLocationId")
+		}
+		if transaction.ToInventoryLocationID == transaction.InventoryLocationID {
+			return errors.New("cannot transfer inventory to the same location")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package c7api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExternalStockCount is one SKU/location count from an outside system of
+// record, such as a warehouse.
+type ExternalStockCount struct {
+	Sku                 string
+	InventoryLocationID string
+	Quantity            int // Physical bottles on hand, compared against InventoryLevel.OnHandCount
+}
+
+// Outcomes of comparing one SKU/location against Commerce7.
+const (
+	ReconcileMatch      = "Match"      // Counts agree
+	ReconcileOver       = "Over"       // Commerce7 has more than the external count
+	ReconcileUnder      = "Under"      // Commerce7 has less than the external count
+	ReconcileMissingSku = "MissingSku" // Commerce7 has no inventory for the SKU anywhere
+)
+
+// ReconcileOptions controls what ReconcileInventory does with the differences
+// it finds.
+type ReconcileOptions struct {
+	DryRun bool   // Only report, never post transactions
+	Reason string // Reason for correcting transactions, defaults to InventoryReasonCountCorrection
+	Notes  string // Notes for correcting transactions
+}
+
+// ReconciliationLine is the comparison for one SKU/location.
+type ReconciliationLine struct {
+	Sku                 string // As Commerce7 spells it, or as the caller did for a missing SKU
+	InventoryLocationID string
+	External            int
+	C7                  int    // On hand in Commerce7, including reserved and allocated stock
+	Delta               int    // External - C7, the adjustment needed to match
+	Status              string // See Reconcile constants
+	Applied             bool   // A correcting transaction was posted
+	Err                 error  // Posting the correction failed
+}
+
+// InventoryReconciliation is the full report from ReconcileInventory.
+type InventoryReconciliation struct {
+	Lines   []ReconciliationLine
+	Applied int // Corrections posted
+	Failed  int // Corrections that failed to post
+}
+
+// Mismatches returns the lines that don't match, including missing SKUs.
+func (r *InventoryReconciliation) Mismatches() []ReconciliationLine {
+	lines := []ReconciliationLine{}
+	for _, line := range r.Lines {
+		if line.Status != ReconcileMatch {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ReconcileInventory compares external counts against Commerce7 and, unless
+// opts.DryRun is set, posts adjustments to bring Commerce7 in line.
+//
+// External counts are physical counts, so they are compared against on-hand
+// stock (see InventoryLevel.OnHandCount) rather than what is available for
+// sale, which would report reserved and allocated bottles as shrinkage.
+//
+// The external counts are treated as the source of truth, but only for the
+// SKU/locations they list: anything else in Commerce7 is left alone, so a
+// partial count can't zero out the rest of the inventory. Duplicate records
+// for the same SKU/location are summed. A SKU Commerce7 has never stocked
+// anywhere is reported as missing rather than created, since there's no
+// variant to attach it to.
+//
+// Failed corrections are recorded on their line and don't stop the rest.
+func ReconcileInventory(counts []ExternalStockCount, opts ReconcileOptions, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*InventoryReconciliation, error) {
+	if opts.Reason == "" {
+		opts.Reason = InventoryReasonCountCorrection
+	}
+	if !IsValidInventoryReason(opts.Reason) {
+		return nil, fmt.Errorf("invalid inventory reason %q, must be one of: %s", opts.Reason, strings.Join(GetValidInventoryReasons(), ", "))
+	}
+
+	type key struct{ sku, location string }
+
+	external := map[key]int{}
+	callerSkus := map[string]string{} // lowercased -> as first spelled in counts
+	for _, count := range counts {
+		if count.Sku == "" || count.InventoryLocationID == "" {
+			return nil, fmt.Errorf("external count missing sku or location: %+v", count)
+		}
+		sku := strings.ToLower(count.Sku)
+		external[key{sku, count.InventoryLocationID}] += count.Quantity
+		if _, ok := callerSkus[sku]; !ok {
+			callerSkus[sku] = count.Sku
+		}
+	}
+
+	levels, err := GetInventory(nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	c7 := map[key]InventoryLevel{}
+	knownSkus := map[string]string{} // lowercased -> as Commerce7 spells it
+	for _, level := range *levels {
+		sku := strings.ToLower(level.Sku)
+		c7[key{sku, level.InventoryLocationID}] = level
+		knownSkus[sku] = level.Sku
+	}
+
+	report := &InventoryReconciliation{Lines: make([]ReconciliationLine, 0, len(external))}
+	for k, quantity := range external {
+		line := ReconciliationLine{
+			Sku:                 callerSkus[k.sku],
+			InventoryLocationID: k.location,
+			External:            quantity,
+		}
+
+		sku, known := knownSkus[k.sku]
+		if !known {
+			line.Status = ReconcileMissingSku
+			line.Delta = quantity
+			report.Lines = append(report.Lines, line)
+			continue
+		}
+		line.Sku = sku
+
+		// Known SKU with no record at this location counts as zero there.
+		line.C7 = c7[k].OnHandCount()
+		line.Delta = line.External - line.C7
+
+		switch {
+		case line.Delta == 0:
+			line.Status = ReconcileMatch
+		case line.Delta < 0:
+			line.Status = ReconcileOver
+		default:
+			line.Status = ReconcileUnder
+		}
+		report.Lines = append(report.Lines, line)
+	}
+
+	sort.Slice(report.Lines, func(i, j int) bool {
+		a, b := report.Lines[i], report.Lines[j]
+		if a.Sku != b.Sku {
+			return a.Sku < b.Sku
+		}
+		return a.InventoryLocationID < b.InventoryLocationID
+	})
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for i := range report.Lines {
+		line := &report.Lines[i]
+		if line.Status != ReconcileOver && line.Status != ReconcileUnder {
+			continue
+		}
+
+		_, err := PostInventoryTransaction(&InventoryTransactionPost{
+			Sku:                 line.Sku,
+			InventoryLocationID: line.InventoryLocationID,
+			TransactionType:     InventoryTransactionTypeAdjust,
+			Reason:              opts.Reason,
+			Quantity:            line.Delta,
+			Notes:               opts.Notes,
+		}, tenant, c7AppAuthEncoded, retryCount, rl)
+		if err != nil {
+			line.Err = err
+			report.Failed++
+			continue
+		}
+		line.Applied = true
+		report.Applied++
+	}
+
+	return report, nil
+}
@@ -0,0 +1,183 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestTransferInventory(t *testing.T) {
+	var posted InventoryTransactionPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/inventory-transaction":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &posted)
+			w.Write([]byte(`{"id":"tx1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/inventory":
+			if sku := r.URL.Query().Get("sku"); sku != "CAB21-750" {
+				t.Errorf("sku = %q", sku)
+			}
+			// The search can match other SKUs too, which must not be taken
+			// for the transferred one.
+			w.Write([]byte(`{"total":3,"inventory":[
+				{"sku":"CAB21-750-MAG","inventoryLocationId":"tasting-room","availableForSaleCount":3},
+				{"sku":"CAB21-750","inventoryLocationId":"warehouse","availableForSaleCount":88},
+				{"sku":"cab21-750","inventoryLocationId":"tasting-room","availableForSaleCount":12}
+			]}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	from, to, err := TransferInventory("CAB21-750", "warehouse", "tasting-room", 12, "", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if posted.TransactionType != InventoryTransactionTypeTransfer || posted.ToInventoryLocationID != "tasting-room" || posted.Quantity != 12 {
+		t.Errorf("posted %+v", posted)
+	}
+	if from.AvailableForSaleCount != 88 {
+		t.Errorf("from = %+v", from)
+	}
+	if to.InventoryLocationID != "tasting-room" || to.AvailableForSaleCount != 12 {
+		t.Errorf("to = %+v", to)
+	}
+}
+
+// A level missing after the transfer is reported, not made up as zero.
+func TestTransferInventory_MissingLevel(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"id":"tx1"}`))
+			return
+		}
+		w.Write([]byte(`{"total":1,"inventory":[
+			{"sku":"CAB21-750","inventoryLocationId":"warehouse","availableForSaleCount":88}
+		]}`))
+	}))
+
+	if _, _, err := TransferInventory("CAB21-750", "warehouse", "tasting-room", 12, "", "t", "a", 0, nil); err == nil {
+		t.Error("expected an error when the destination level can't be read back")
+	}
+}
+
+func TestReceiveInventory(t *testing.T) {
+	levels := `{"total":1,"inventory":[{"sku":"CAB21-750","inventoryLocationId":"warehouse","availableForSaleCount":100}]}`
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"id":"tx1"}`))
+			return
+		}
+		w.Write([]byte(levels))
+	}))
+
+	level, err := ReceiveInventory("CAB21-750", "warehouse", 12, "", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if level.AvailableForSaleCount != 100 {
+		t.Errorf("level = %+v", level)
+	}
+
+	// As with a transfer, a level missing after the write is reported.
+	levels = `{"total":0,"inventory":[]}`
+	if _, err := AdjustInventory("CAB21-750", "warehouse", -2, InventoryReasonDamaged, "", "t", "a", 0, nil); err == nil {
+		t.Error("expected an error when the level can't be read back")
+	}
+}
+
+func TestValidateInventoryTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      InventoryTransactionPost
+		wantErr bool
+	}{
+		{"adjustment", InventoryTransactionPost{Sku: "A", InventoryLocationID: "l1", TransactionType: InventoryTransactionTypeAdjust, Reason: InventoryReasonDamaged, Quantity: -2}, false},
+		{"bad reason", InventoryTransactionPost{Sku: "A", InventoryLocationID: "l1", TransactionType: InventoryTransactionTypeAdjust, Reason: "Broke it", Quantity: -2}, true},
+		{"bad type", InventoryTransactionPost{Sku: "A", InventoryLocationID: "l1", TransactionType: "Teleport", Reason: InventoryReasonOther, Quantity: 1}, true},
+		{"transfer no destination", InventoryTransactionPost{Sku: "A", InventoryLocationID: "l1", TransactionType: InventoryTransactionTypeTransfer, Reason: InventoryReasonTransfer, Quantity: 1}, true},
+		{"transfer to self", InventoryTransactionPost{Sku: "A", InventoryLocationID: "l1", ToInventoryLocationID: "l1", TransactionType: InventoryTransactionTypeTransfer, Reason: InventoryReasonTransfer, Quantity: 1}, true},
+		{"no location", InventoryTransactionPost{Sku: "A", TransactionType: InventoryTransactionTypeReceive, Reason: InventoryReasonReceived, Quantity: 1}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateInventoryTransaction(&tc.tx); (err != nil) != tc.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconcileInventory(t *testing.T) {
+	var posted []InventoryTransactionPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/inventory":
+			w.Write([]byte(`{"total":3,"inventory":[
+				{"sku":"CAB","inventoryLocationId":"wh","availableForSaleCount":7,"reserveCount":2,"allocatedCount":1},
+				{"sku":"CHARD","inventoryLocationId":"wh","availableForSaleCount":5},
+				{"sku":"ROSE","inventoryLocationId":"wh","availableForSaleCount":7}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/inventory-transaction":
+			var tx InventoryTransactionPost
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &tx)
+			posted = append(posted, tx)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	counts := []ExternalStockCount{
+		{Sku: "CAB", InventoryLocationID: "wh", Quantity: 10},
+		{Sku: "chard", InventoryLocationID: "wh", Quantity: 3},
+		{Sku: "CHARD", InventoryLocationID: "wh", Quantity: 1},
+		{Sku: "ROSE", InventoryLocationID: "tr", Quantity: 6},
+		{Sku: "MERLOT", InventoryLocationID: "wh", Quantity: 2},
+	}
+
+	report, err := ReconcileInventory(counts, ReconcileOptions{DryRun: true}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posted) != 0 {
+		t.Fatalf("dry run posted %d transactions", len(posted))
+	}
+
+	want := map[string]struct {
+		status string
+		delta  int
+	}{
+		"CAB/wh":    {ReconcileMatch, 0},      // reserved and allocated bottles are on hand
+		"CHARD/wh":  {ReconcileOver, -1},      // duplicate counts summed to 4
+		"ROSE/tr":   {ReconcileUnder, 6},      // known SKU, new location
+		"MERLOT/wh": {ReconcileMissingSku, 2}, // the caller's spelling
+	}
+	if len(report.Lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %+v", len(report.Lines), len(want), report.Lines)
+	}
+	for _, line := range report.Lines {
+		w, ok := want[line.Sku+"/"+line.InventoryLocationID]
+		if !ok || line.Status != w.status || line.Delta != w.delta {
+			t.Errorf("line %+v, want %+v", line, w)
+		}
+	}
+
+	report, err = ReconcileInventory(counts, ReconcileOptions{}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Applied != 2 || len(posted) != 2 {
+		t.Fatalf("applied %d, posted %d, want 2 corrections", report.Applied, len(posted))
+	}
+	for _, tx := range posted {
+		if tx.TransactionType != InventoryTransactionTypeAdjust || tx.Reason != InventoryReasonCountCorrection {
+			t.Errorf("posted %+v", tx)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderBuilder assembles an OrderPost for a POS or Inbound order.
+//
+//	order, err := c7api.NewOrderBuilder(c7api.OrderChannelPOS).
+//	    Customer(customerId).
+//	    CarryOut(locationId).
+//	    AddItemBySku("CAB21-750", 2).
+//	    Tender(c7api.TenderPost{TenderType: c7api.TenderTypeCash, AmountTendered: c7api.NewMoney(9000, "USD")}).
+//	    Create(tenant, auth, 3, rl)
+//
+// Items added by SKU are resolved to variants, prices and tax types when the
+// order is created. Setter methods never fail; everything is checked by
+// Validate, which Create calls before any request is made.
+type OrderBuilder struct {
+	order     OrderPost
+	unmatched []string // SKUs given to ItemTax that matched no line
+}
+
+// NewOrderBuilder starts an order on channel, which must be OrderChannelPOS
+// or OrderChannelInbound. Club and Web orders come from Commerce7 itself.
+func NewOrderBuilder(channel string) *OrderBuilder {
+	return &OrderBuilder{order: OrderPost{Channel: channel}}
+}
+
+func (b *OrderBuilder) Customer(customerId string) *OrderBuilder {
+	b.order.CustomerID = customerId
+	return b
+}
+
+func (b *OrderBuilder) BillTo(billTo Address) *OrderBuilder {
+	b.order.BillTo = &billTo
+	return b
+}
+
+// Ship sets the delivery method to Ship, to the given address.
+func (b *OrderBuilder) Ship(shipTo Address) *OrderBuilder {
+	b.order.OrderDeliveryMethod = OrderDeliveryMethodShip
+	b.order.ShipTo = &shipTo
+	b.order.InventoryLocationID = ""
+	return b
+}
+
+// Pickup sets the delivery method to Pickup, from the given location.
+func (b *OrderBuilder) Pickup(inventoryLocationId string) *OrderBuilder {
+	b.order.OrderDeliveryMethod = OrderDeliveryMethodPickup
+	b.order.InventoryLocationID = inventoryLocationId
+	b.order.ShipTo = nil
+	return b
+}
+
+// CarryOut sets the delivery method to Carry Out, from the given location.
+func (b *OrderBuilder) CarryOut(inventoryLocationId string) *OrderBuilder {
+	b.order.OrderDeliveryMethod = OrderDeliveryMethodCarryout
+	b.order.InventoryLocationID = inventoryLocationId
+	b.order.ShipTo = nil
+	return b
+}
+
+// AddItem adds a line for a known variant at the given price.
+func (b *OrderBuilder) AddItem(productVariantId string, sku string, quantity int, price Money, taxType string) *OrderBuilder {
+	b.order.Items = append(b.order.Items, OrderItemPost{
+		ProductVariantID: productVariantId,
+		Sku:              sku,
+		Quantity:         quantity,
+		Price:            price,
+		TaxType:          taxType,
+	})
+	return b
+}
+
+// AddItemBySku adds a line to be resolved to its variant, price and tax type
+// when the order is created.
+func (b *OrderBuilder) AddItemBySku(sku string, quantity int) *OrderBuilder {
+	b.order.Items = append(b.order.Items, OrderItemPost{Sku: sku, Quantity: quantity})
+	return b
+}
+
+// ItemTax overrides the calculated tax on every line added so far for sku,
+// matched without regard to case as Create does. A sku that matches no line
+// fails Validate rather than being dropped.
+func (b *OrderBuilder) ItemTax(sku string, tax Money) *OrderBuilder {
+	matched := false
+	for i := range b.order.Items {
+		if strings.EqualFold(b.order.Items[i].Sku, sku) {
+			t := tax
+			b.order.Items[i].Tax = &t
+			matched = true
+		}
+	}
+	if !matched {
+		b.unmatched = append(b.unmatched, sku)
+	}
+	return b
+}
+
+// NonTaxable marks the whole order as exempt from tax.
+func (b *OrderBuilder) NonTaxable() *OrderBuilder {
+	b.order.IsNonTaxable = true
+	return b
+}
+
+func (b *OrderBuilder) Shipping(shipping OrderShippingPost) *OrderBuilder {
+	b.order.Shipping = append(b.order.Shipping, shipping)
+	return b
+}
+
+func (b *OrderBuilder) Tender(tender TenderPost) *OrderBuilder {
+	b.order.Tenders = append(b.order.Tenders, tender)
+	return b
+}
+
+func (b *OrderBuilder) SubmittedAt(submitted time.Time) *OrderBuilder {
+	b.order.OrderSubmittedDate = &submitted
+	return b
+}
+
+func (b *OrderBuilder) SalesAttribute(code string) *OrderBuilder {
+	b.order.SalesAttributeCode = code
+	return b
+}
+
+// Validate checks the order as it stands. Lines added by SKU only need their
+// SKU here, since they are resolved on Create.
+func (b *OrderBuilder) Validate() error {
+	if len(b.unmatched) > 0 {
+		return fmt.Errorf("tax override for %s matches no order item", strings.Join(b.unmatched, ", "))
+	}
+	return validateOrderPost(&b.order, false)
+}
+
+// Order returns a copy of the payload built so far.
+func (b *OrderBuilder) Order() OrderPost {
+	order := b.order
+	order.Items = append([]OrderItemPost(nil), b.order.Items...)
+	return order
+}
+
+// Create resolves any SKU-only lines, validates the order and posts it.
+func (b *OrderBuilder) Create(tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	order := b.Order()
+	resolved := map[string]*C7ProductVariant{}
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ProductVariantID != "" {
+			continue
+		}
+
+		key := strings.ToLower(item.Sku)
+		variant, ok := resolved[key]
+		if !ok {
+			_, v, err := GetProductBySku(item.Sku, tenant, c7AppAuthEncoded, retryCount, rl)
+			if err != nil {
+				return nil, fmt.Errorf("while resolving order item: %w", err)
+			}
+			variant = v
+			resolved[key] = v
+		}
+
+		item.ProductVariantID = variant.ID
+		item.Sku = variant.Sku
+		item.Price = Money{Cents: int64(variant.Price)}
+		item.TaxType = variant.TaxType
+	}
+
+	return CreateOrder(&order, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// CreateOrder validates a fully resolved order and posts it, returning the
+// order as created by Commerce7.
+func CreateOrder(order *OrderPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	if err := validateOrderPost(order, true); err != nil {
+		return nil, err
+	}
+
+	resp, err := Post(order, Endpoints.Order, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	var created C7Order
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order after create: %w", err)
+	}
+	return &created, nil
+}
+
+// validateOrderPost checks order. With resolved set, every line must also
+// carry its variant.
+func validateOrderPost(order *OrderPost, resolved bool) error {
+	if order == nil {
+		return errors.New("order cannot be nil")
+	}
+	if order.Channel != OrderChannelPOS && order.Channel != OrderChannelInbound {
+		return fmt.Errorf("invalid order channel %q, must be %s or %s", order.Channel, OrderChannelPOS, OrderChannelInbound)
+	}
+	if order.CustomerID == "" {
+		return errors.New("order requires a customer")
+	}
+
+	switch order.OrderDeliveryMethod {
+	case OrderDeliveryMethodShip:
+		if err := validateShipTo(order.ShipTo); err != nil {
+			return err
+		}
+	case OrderDeliveryMethodPickup, OrderDeliveryMethodCarryout:
+		if order.InventoryLocationID == "" {
+			return fmt.Errorf("%s orders require an inventory location", order.OrderDeliveryMethod)
+		}
+	case "":
+		return errors.New("order requires a delivery method")
+	default:
+		return fmt.Errorf("invalid order delivery method: %s", order.OrderDeliveryMethod)
+	}
+
+	if len(order.Items) == 0 {
+		return errors.New("order requires at least one item")
+	}
+	for i, item := range order.Items {
+		if item.Sku == "" && item.ProductVariantID == "" {
+			return fmt.Errorf("item %d: sku or productVariantId is required", i)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be positive", i)
+		}
+		if resolved && item.ProductVariantID == "" {
+			return fmt.Errorf("item %d: productVariantId is required", i)
+		}
+		if item.Price.IsNegative() {
+			return fmt.Errorf("item %d: price cannot be negative", i)
+		}
+		if item.TaxType != "" && !IsValidTaxType(item.TaxType) {
+			return fmt.Errorf("item %d: invalid tax type: %s", i, item.TaxType)
+		}
+		if item.Tax != nil && order.IsNonTaxable && !item.Tax.IsZero() {
+			return fmt.Errorf("item %d: tax override on a non-taxable order", i)
+		}
+	}
+
+	if order.Channel == OrderChannelPOS || len(order.Tenders) > 0 {
+		if err := validateTenders(order.Tenders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateShipTo(shipTo *Address) error {
+	if shipTo == nil {
+		return errors.New("ship orders require a shipTo address")
+	}
+	if shipTo.FirstName == "" || shipTo.LastName == "" {
+		return errors.New("shipTo requires a first and last name")
+	}
+	if shipTo.Address == "" || shipTo.City == "" || shipTo.StateCode == "" || shipTo.ZipCode == "" || shipTo.CountryCode == "" {
+		return errors.New("shipTo requires address, city, stateCode, zipCode and countryCode")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestOrderBuilder_Validate(t *testing.T) {
+	shipTo := Address{FirstName: "Ada", LastName: "Lovelace", Address: "1 Vine St", City: "Napa", StateCode: "CA", ZipCode: "94558", CountryCode: "US"}
+	cash := TenderPost{TenderType: TenderTypeCash, AmountTendered: Money{Cents: 9000}}
+
+	tests := []struct {
+		name    string
+		builder *OrderBuilder
+		wantErr bool
+	}{
+		{"valid pos carry out", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 1).Tender(cash), false},
+		{"valid inbound ship without tenders", NewOrderBuilder(OrderChannelInbound).Customer("c1").Ship(shipTo).AddItemBySku("CAB", 1), false},
+		{"web channel", NewOrderBuilder(OrderChannelWeb).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 1).Tender(cash), true},
+		{"no customer", NewOrderBuilder(OrderChannelPOS).CarryOut("loc1").AddItemBySku("CAB", 1).Tender(cash), true},
+		{"no delivery method", NewOrderBuilder(OrderChannelPOS).Customer("c1").AddItemBySku("CAB", 1).Tender(cash), true},
+		{"pickup without location", NewOrderBuilder(OrderChannelPOS).Customer("c1").Pickup("").AddItemBySku("CAB", 1).Tender(cash), true},
+		{"ship without zip", NewOrderBuilder(OrderChannelInbound).Customer("c1").Ship(Address{FirstName: "Ada", LastName: "L", Address: "1 Vine St", City: "Napa", StateCode: "CA", CountryCode: "US"}).AddItemBySku("CAB", 1), true},
+		{"no items", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").Tender(cash), true},
+		{"zero quantity", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 0).Tender(cash), true},
+		{"pos without tenders", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 1), true},
+		{"tax override for a sku with no line", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 1).ItemTax("MERLOT", Money{Cents: 50}).Tender(cash), true},
+		{"tax override on non-taxable", NewOrderBuilder(OrderChannelPOS).Customer("c1").CarryOut("loc1").AddItemBySku("CAB", 1).ItemTax("CAB", Money{Cents: 50}).NonTaxable().Tender(cash), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.builder.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderBuilder_CreateResolvesSkus(t *testing.T) {
+	var posted OrderPost
+	var productLookups int
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/product":
+			productLookups++
+			w.Write([]byte(`{"products":[{"id":"p1","title":"Cabernet","variants":[{"id":"v1","sku":"CAB21-750","price":4500,"taxType":"Wine"}]}],"total":1}`))
+		case "/order":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &posted)
+			w.Write([]byte(`{"id":"o1","orderNumber":2001}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	order, err := NewOrderBuilder(OrderChannelPOS).
+		Customer("c1").
+		CarryOut("loc1").
+		AddItemBySku("cab21-750", 1).
+		AddItemBySku("CAB21-750", 1).
+		ItemTax("Cab21-750", Money{Cents: 371}).
+		Tender(TenderPost{TenderType: TenderTypeCash, AmountTendered: Money{Cents: 9000}}).
+		Create("t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.OrderNumber != 2001 {
+		t.Errorf("order = %+v", order)
+	}
+	if productLookups != 1 {
+		t.Errorf("looked up the product %d times, want 1", productLookups)
+	}
+	if len(posted.Items) != 2 || posted.Items[0].ProductVariantID != "v1" || posted.Items[0].Sku != "CAB21-750" || posted.Items[0].Price.Cents != 4500 {
+		t.Errorf("posted items = %+v", posted.Items)
+	}
+	for _, item := range posted.Items {
+		if item.Tax == nil || item.Tax.Cents != 371 {
+			t.Errorf("item tax = %v, want the override on every line", item.Tax)
+		}
+	}
+	if posted.OrderDeliveryMethod != OrderDeliveryMethodCarryout || posted.InventoryLocationID != "loc1" {
+		t.Errorf("posted = %+v", posted)
+	}
+}
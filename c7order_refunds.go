@@ -0,0 +1,247 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// OrderRefundSummary is an order alongside the refunds made against it. All
+// refunded amounts are positive, and the Net fields are what the order is
+// worth once the refunds are taken off.
+type OrderRefundSummary struct {
+	OrderID            string
+	OrderNumber        int
+	Refunds            []C7Order
+	RefundedQuantities map[string]int // Keyed by original order item id
+	RefundedSubTotal   Money
+	RefundedShipping   Money
+	RefundedTax        Money
+	RefundedTotal      Money
+	NetSubTotal        Money
+	NetShipTotal       Money
+	NetTaxTotal        Money
+	NetTotal           Money
+}
+
+// RemainingQuantity is how many units of an original order line have not
+// been refunded yet.
+func (s *OrderRefundSummary) RemainingQuantity(order *C7Order, orderItemId string) int {
+	for _, item := range order.Items {
+		if item.ID == orderItemId {
+			return item.Quantity - s.RefundedQuantities[orderItemId]
+		}
+	}
+	return 0
+}
+
+// IsFullyRefunded reports whether nothing is left to refund on the order.
+func (s *OrderRefundSummary) IsFullyRefunded() bool {
+	return s.NetTotal.Cents <= 0
+}
+
+// GetOrderRefunds returns orderId together with its refund history.
+func GetOrderRefunds(orderId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, *OrderRefundSummary, error) {
+	if orderId == "" {
+		return nil, nil, errors.New("no orderId provided")
+	}
+
+	order, err := GetOrderFromId[C7Order](orderId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	queries := map[string]string{"purchaseType": OrderPurchaseTypeRefund, "refundOrderId": orderId}
+	candidates, err := GetAll[C7Order, C7Orders](Endpoints.Order, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get refunds for order: %w", err)
+	}
+
+	// Only trust refunds that point back at this order, in case the filters
+	// are looser than asked for.
+	refunds := make([]C7Order, 0, len(*candidates))
+	for _, refund := range *candidates {
+		if refund.RefundOrderID == orderId && refund.PurchaseType == OrderPurchaseTypeRefund {
+			refunds = append(refunds, refund)
+		}
+	}
+
+	return order, BuildOrderRefundSummary(order, refunds), nil
+}
+
+// BuildOrderRefundSummary totals refunds against order. Refund lines are
+// matched back to the original lines by variant, filling earlier lines first
+// when a variant appears more than once.
+func BuildOrderRefundSummary(order *C7Order, refunds []C7Order) *OrderRefundSummary {
+	summary := &OrderRefundSummary{
+		OrderID:            order.ID,
+		OrderNumber:        order.OrderNumber,
+		Refunds:            refunds,
+		RefundedQuantities: map[string]int{},
+	}
+
+	refundedByVariant := map[string]int{}
+	for _, refund := range refunds {
+		summary.RefundedSubTotal = summary.RefundedSubTotal.Add(refund.SubTotal.Abs())
+		summary.RefundedShipping = summary.RefundedShipping.Add(refund.ShipTotal.Abs())
+		summary.RefundedTax = summary.RefundedTax.Add(refund.TaxTotal.Abs())
+		summary.RefundedTotal = summary.RefundedTotal.Add(refund.Total.Abs())
+		for _, item := range refund.Items {
+			refundedByVariant[item.ProductVariantID] += absInt(item.Quantity)
+		}
+	}
+
+	for _, item := range order.Items {
+		n := min(refundedByVariant[item.ProductVariantID], item.Quantity)
+		if n == 0 {
+			continue
+		}
+		summary.RefundedQuantities[item.ID] += n
+		refundedByVariant[item.ProductVariantID] -= n
+	}
+
+	summary.NetSubTotal = order.SubTotal.Sub(summary.RefundedSubTotal)
+	summary.NetShipTotal = order.ShipTotal.Sub(summary.RefundedShipping)
+	summary.NetTaxTotal = order.TaxTotal.Sub(summary.RefundedTax)
+	summary.NetTotal = order.Total.Sub(summary.RefundedTotal)
+	return summary
+}
+
+// RefundOrder refunds everything left on orderId: every unrefunded unit and
+// any shipping not yet refunded.
+func RefundOrder(orderId string, restock bool, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	order, summary, err := GetOrderRefunds(orderId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRefundable(order); err != nil {
+		return nil, err
+	}
+	if summary.IsFullyRefunded() {
+		return nil, fmt.Errorf("order %d is already fully refunded", order.OrderNumber)
+	}
+
+	refund := RefundPost{IsRestock: restock, Notes: notes}
+	for _, item := range order.Items {
+		if remaining := summary.RemainingQuantity(order, item.ID); remaining > 0 {
+			refund.Items = append(refund.Items, RefundItemPost{OrderItemID: item.ID, Quantity: remaining})
+		}
+	}
+	if summary.NetShipTotal.Cents > 0 {
+		refund.ShippingAmount = summary.NetShipTotal
+	}
+
+	if err := validateRefund(order, summary, &refund); err != nil {
+		return nil, err
+	}
+	return postRefund(orderId, &refund, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// RefundOrderItems refunds specific lines and quantities of orderId, checking
+// that no line is refunded past what was bought. shippingAmount may be zero.
+func RefundOrderItems(orderId string, items []RefundItemPost, shippingAmount Money, restock bool, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	if len(items) == 0 {
+		return nil, errors.New("no refund items provided")
+	}
+
+	order, summary, err := GetOrderRefunds(orderId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := RefundPost{Items: items, ShippingAmount: shippingAmount, IsRestock: restock, Notes: notes}
+	if err := validateRefund(order, summary, &refund); err != nil {
+		return nil, err
+	}
+	return postRefund(orderId, &refund, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// RefundOrderShipping refunds amount of shipping on orderId and nothing else.
+func RefundOrderShipping(orderId string, amount Money, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	order, summary, err := GetOrderRefunds(orderId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := RefundPost{Items: []RefundItemPost{}, ShippingAmount: amount, Notes: notes}
+	if err := validateRefund(order, summary, &refund); err != nil {
+		return nil, err
+	}
+	return postRefund(orderId, &refund, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// validateRefundable checks that order is one refunds can be made against.
+func validateRefundable(order *C7Order) error {
+	if order.PurchaseType == OrderPurchaseTypeRefund {
+		return errors.New("cannot refund a refund order")
+	}
+	return nil
+}
+
+func validateRefund(order *C7Order, summary *OrderRefundSummary, refund *RefundPost) error {
+	if err := validateRefundable(order); err != nil {
+		return err
+	}
+	if len(refund.Items) == 0 && refund.ShippingAmount.IsZero() {
+		return errors.New("refund has no items or shipping")
+	}
+	if refund.ShippingAmount.IsNegative() {
+		return errors.New("refund shipping amount cannot be negative")
+	}
+	if refund.ShippingAmount.Cmp(summary.NetShipTotal) > 0 {
+		return fmt.Errorf("refund shipping amount %s exceeds the %s remaining", refund.ShippingAmount, summary.NetShipTotal)
+	}
+
+	requested := map[string]int{}
+	for _, item := range refund.Items {
+		if item.OrderItemID == "" {
+			return errors.New("refund item requires an orderItemId")
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("refund item %s: quantity must be positive", item.OrderItemID)
+		}
+		requested[item.OrderItemID] += item.Quantity
+	}
+	for id, quantity := range requested {
+		found := false
+		for _, item := range order.Items {
+			if item.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("refund item %s is not on order %d", id, order.OrderNumber)
+		}
+		if remaining := summary.RemainingQuantity(order, id); quantity > remaining {
+			return fmt.Errorf("refund item %s: quantity %d exceeds the %d remaining", id, quantity, remaining)
+		}
+	}
+
+	if len(refund.Tenders) > 0 {
+		return validateTenders(refund.Tenders)
+	}
+	return nil
+}
+
+// postRefund sends refund against orderId, returning the refund order.
+func postRefund(orderId string, refund *RefundPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Order, error) {
+	url := Endpoints.Order + "/" + orderId + "/refund"
+	resp, err := Post(refund, url, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund order: %w", err)
+	}
+
+	var refundOrder C7Order
+	if err := json.Unmarshal(*resp, &refundOrder); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refund order: %w", err)
+	}
+	return &refundOrder, nil
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const refundTestOrder = `{"id":"o1","orderNumber":1001,"purchaseType":"Order","subTotal":9000,"shipTotal":1500,"taxTotal":700,"total":11200,
+	"items":[{"id":"i1","productVariantId":"v1","sku":"CAB","quantity":2,"price":4500}]}`
+
+const refundTestRefunds = `{"orders":[
+	{"id":"r1","orderNumber":1002,"purchaseType":"Refund","refundOrderId":"o1","subTotal":-4500,"shipTotal":0,"taxTotal":-350,"total":-4850,
+	 "items":[{"id":"ri1","productVariantId":"v1","sku":"CAB","quantity":-1,"price":-4500}]},
+	{"id":"x1","orderNumber":1003,"purchaseType":"Refund","refundOrderId":"other","total":-100}
+],"total":2}`
+
+func TestGetOrderRefunds_NetAmounts(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/order/o1":
+			w.Write([]byte(refundTestOrder))
+		case "/order":
+			w.Write([]byte(refundTestRefunds))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	order, summary, err := GetOrderRefunds("o1", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summary.Refunds) != 1 {
+		t.Fatalf("refunds = %d, want 1", len(summary.Refunds))
+	}
+	if summary.RefundedTotal.Cents != 4850 || summary.NetTotal.Cents != 6350 || summary.NetShipTotal.Cents != 1500 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if got := summary.RemainingQuantity(order, "i1"); got != 1 {
+		t.Errorf("remaining = %d, want 1", got)
+	}
+	if summary.IsFullyRefunded() {
+		t.Error("order reported as fully refunded")
+	}
+}
+
+func TestRefundOrderItems(t *testing.T) {
+	var posted RefundPost
+	var refundPosts int
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/order/o1":
+			w.Write([]byte(refundTestOrder))
+		case "/order":
+			w.Write([]byte(refundTestRefunds))
+		case "/order/r1":
+			w.Write([]byte(`{"id":"r1","orderNumber":1002,"purchaseType":"Refund","refundOrderId":"o1","shipTotal":-500,
+				"items":[{"id":"ri1","productVariantId":"v1","sku":"CAB","quantity":1,"price":-4500}]}`))
+		case "/order/o1/refund", "/order/r1/refund":
+			refundPosts++
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &posted)
+			w.Write([]byte(`{"id":"r2","orderNumber":1004,"purchaseType":"Refund","refundOrderId":"o1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	// One unit is already refunded, so two more is too many.
+	if _, err := RefundOrderItems("o1", []RefundItemPost{{OrderItemID: "i1", Quantity: 2}}, Money{}, true, "", "t", "a", 0, nil); err == nil {
+		t.Error("expected an error refunding more than remains")
+	}
+	if _, err := RefundOrderShipping("o1", Money{Cents: 2000}, "", "t", "a", 0, nil); err == nil {
+		t.Error("expected an error refunding more shipping than was charged")
+	}
+	if refundPosts != 0 {
+		t.Fatalf("posted %d refunds for invalid requests", refundPosts)
+	}
+
+	refund, err := RefundOrderItems("o1", []RefundItemPost{{OrderItemID: "i1", Quantity: 1}}, Money{Cents: 500}, true, "damaged", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.OrderNumber != 1004 {
+		t.Errorf("refund = %+v", refund)
+	}
+	if len(posted.Items) != 1 || posted.Items[0].Quantity != 1 || posted.ShippingAmount.Cents != 500 || !posted.IsRestock {
+		t.Errorf("posted = %+v", posted)
+	}
+
+	if _, err := RefundOrder("o1", false, "", "t", "a", 0, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posted.Items) != 1 || posted.Items[0].Quantity != 1 || posted.ShippingAmount.Cents != 1500 {
+		t.Errorf("full refund posted = %+v", posted)
+	}
+
+	refundPosts = 0
+	if _, err := RefundOrder("r1", false, "", "t", "a", 0, nil); err == nil || !strings.Contains(err.Error(), "refund order") {
+		t.Errorf("err = %v, want a refund order to be refused", err)
+	}
+	if refundPosts != 0 {
+		t.Errorf("posted %d refunds of a refund order", refundPosts)
+	}
+}
@@ -0,0 +1,164 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GetProducts returns every product matching queries, walking all pages.
+//
+// Pass nil queries for the full catalog, or Commerce7 filters such as
+// {"q": "cabernet"} or {"type": ProductTypeWine}.
+func GetProducts(queries map[string]string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]C7Product, error) {
+	products, err := GetAll[C7Product, C7Products](Endpoints.Product, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	return products, nil
+}
+
+func GetProductById(productId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, error) {
+	if productId == "" {
+		return nil, errors.New("no productId provided")
+	}
+
+	product, err := Get[C7Product](Endpoints.Product+"/"+productId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+// GetProductBySlug searches for slug and returns the product whose slug
+// matches exactly, since the search also matches titles and partial slugs.
+func GetProductBySlug(slug string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, error) {
+	if slug == "" {
+		return nil, errors.New("no slug provided")
+	}
+
+	products, err := GetProducts(map[string]string{"q": slug}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range *products {
+		if strings.EqualFold(product.Slug, slug) {
+			return &product, nil
+		}
+	}
+	return nil, fmt.Errorf("no product found with slug: %s", slug)
+}
+
+// GetProductBySku searches for sku and returns the product and the variant
+// carrying it. SKUs are matched exactly, case-insensitively.
+func GetProductBySku(sku string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, *C7ProductVariant, error) {
+	if sku == "" {
+		return nil, nil, errors.New("no sku provided")
+	}
+
+	products, err := GetProducts(map[string]string{"q": sku}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range *products {
+		product := &(*products)[i]
+		for j := range product.Variants {
+			if strings.EqualFold(product.Variants[j].Sku, sku) {
+				return product, &product.Variants[j], nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no product found with sku: %s", sku)
+}
+
+// CreateProduct validates product client-side and posts it, returning the
+// product as created by Commerce7.
+func CreateProduct(product *ProductPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, error) {
+	if err := ValidateProductPost(product); err != nil {
+		return nil, err
+	}
+
+	resp, err := Post(product, Endpoints.Product, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	var created C7Product
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product after create: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdateProduct validates product client-side and puts it over productId.
+func UpdateProduct(productId string, product *ProductPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*C7Product, error) {
+	if productId == "" {
+		return nil, errors.New("no productId provided")
+	}
+	if err := ValidateProductPost(product); err != nil {
+		return nil, err
+	}
+
+	resp, err := Put(product, Endpoints.Product+"/"+productId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	var updated C7Product
+	if err := json.Unmarshal(*resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal product after update: %w", err)
+	}
+	return &updated, nil
+}
+
+func DeleteProductById(productId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) error {
+	if productId == "" {
+		return errors.New("no productId provided")
+	}
+
+	_, err := RequestWithRetryAndRead(http.MethodDelete, Endpoints.Product+"/"+productId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	return nil
+}
+
+// ValidateProductPost checks the product against the enums Commerce7
+// enforces, so a bad type or status fails before a request is spent on it.
+func ValidateProductPost(product *ProductPost) error {
+	if product == nil {
+		return errors.New("product cannot be nil")
+	}
+	if product.Title == "" {
+		return errors.New("product title is required")
+	}
+	if !IsValidProductType(product.Type) {
+		return fmt.Errorf("invalid product type %q, must be one of: %s", product.Type, strings.Join(GetValidProductTypes(), ", "))
+	}
+	if !IsValidAdminStatus(product.AdminStatus) {
+		return fmt.Errorf("invalid product admin status %q, must be one of: %s", product.AdminStatus, strings.Join(GetValidAdminStatuses(), ", "))
+	}
+	if !IsValidWebStatus(product.WebStatus) {
+		return fmt.Errorf("invalid product web status %q, must be one of: %s", product.WebStatus, strings.Join(GetValidWebStatuses(), ", "))
+	}
+	if len(product.Variants) == 0 {
+		return errors.New("product must have at least one variant")
+	}
+
+	for i, variant := range product.Variants {
+		if variant.Sku == "" {
+			return fmt.Errorf("variant %d: sku is required", i)
+		}
+		if !IsValidTaxType(variant.TaxType) {
+			return fmt.Errorf("variant %s: invalid tax type %q, must be one of: %s", variant.Sku, variant.TaxType, strings.Join(GetValidTaxTypes(), ", "))
+		}
+		if variant.Price < 0 {
+			return fmt.Errorf("variant %s: price cannot be negative", variant.Sku)
+		}
+	}
+	return nil
+}
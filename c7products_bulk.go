@@ -0,0 +1,204 @@
+package c7api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProductChange is the desired state for one SKU in a bulk update. Nil fields
+// are left as they are.
+//
+// AdminStatus and WebStatus belong to the product, not the variant, so they
+// apply to every variant of the product the SKU belongs to.
+type ProductChange struct {
+	Price        *int    // Cents
+	ComparePrice *int    // Cents
+	AdminStatus  *string // See AdminStatus constants
+	WebStatus    *string // See WebStatus constants
+}
+
+// BulkProductUpdateResult reports what happened to one SKU.
+type BulkProductUpdateResult struct {
+	Sku       string
+	ProductID string
+	VariantID string
+	Status    string   // BulkStatusApplied, BulkStatusSkipped or BulkStatusFailed
+	Changes   []string // Human-readable list of the fields that changed, e.g. "price: 4500 -> 4200"
+	Err       error    // Set when Status is BulkStatusFailed
+}
+
+// BulkUpdateProducts applies a set of SKU changes to the catalog.
+//
+// It walks the catalog once to resolve SKUs, diffs each change against the
+// current values, and writes only the products that actually differ, one PUT
+// per product however many of its SKUs changed. All requests go through rl.
+//
+// The results are sorted by SKU. A SKU that doesn't exist, or whose product
+// has conflicting status changes from another SKU, is reported as failed
+// rather than aborting the rest.
+func BulkUpdateProducts(changes map[string]ProductChange, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) ([]BulkProductUpdateResult, error) {
+	if len(changes) == 0 {
+		return []BulkProductUpdateResult{}, nil
+	}
+
+	for sku, change := range changes {
+		if change.AdminStatus != nil && !IsValidAdminStatus(*change.AdminStatus) {
+			return nil, fmt.Errorf("sku %s: invalid admin status %q, must be one of: %s", sku, *change.AdminStatus, strings.Join(GetValidAdminStatuses(), ", "))
+		}
+		if change.WebStatus != nil && !IsValidWebStatus(*change.WebStatus) {
+			return nil, fmt.Errorf("sku %s: invalid web status %q, must be one of: %s", sku, *change.WebStatus, strings.Join(GetValidWebStatuses(), ", "))
+		}
+	}
+
+	catalog, err := GetProducts(nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index variants by SKU, case-insensitively like GetProductBySku.
+	type variantRef struct {
+		product *C7Product
+		variant *C7ProductVariant
+	}
+	bySku := map[string]variantRef{}
+	for i := range *catalog {
+		product := &(*catalog)[i]
+		for j := range product.Variants {
+			bySku[strings.ToLower(product.Variants[j].Sku)] = variantRef{product, &product.Variants[j]}
+		}
+	}
+
+	skus := make([]string, 0, len(changes))
+	for sku := range changes {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	results := make([]BulkProductUpdateResult, len(skus))
+	resultIdx := map[string]int{}
+
+	// Group the real changes by product, so each product is written once.
+	posts := map[string]*ProductPost{}
+	postSkus := map[string][]string{}
+	var productOrder []string
+
+	for i, sku := range skus {
+		change := changes[sku]
+		result := BulkProductUpdateResult{Sku: sku}
+		resultIdx[sku] = i
+
+		ref, ok := bySku[strings.ToLower(sku)]
+		if !ok {
+			result.Status = BulkStatusFailed
+			result.Err = fmt.Errorf("no product found with sku: %s", sku)
+			results[i] = result
+			continue
+		}
+		result.ProductID = ref.product.ID
+		result.VariantID = ref.variant.ID
+
+		result.Changes = diffProductChange(ref.product, ref.variant, change)
+		if len(result.Changes) == 0 {
+			result.Status = BulkStatusSkipped
+			results[i] = result
+			continue
+		}
+		results[i] = result
+
+		post, ok := posts[ref.product.ID]
+		if !ok {
+			p := ref.product.ToPost()
+			post = &p
+			posts[ref.product.ID] = post
+			productOrder = append(productOrder, ref.product.ID)
+		}
+		postSkus[ref.product.ID] = append(postSkus[ref.product.ID], sku)
+
+		for j := range post.Variants {
+			if post.Variants[j].ID != ref.variant.ID {
+				continue
+			}
+			if change.Price != nil {
+				post.Variants[j].Price = *change.Price
+			}
+			if change.ComparePrice != nil {
+				post.Variants[j].ComparePrice = change.ComparePrice
+			}
+		}
+	}
+
+	for _, productId := range productOrder {
+		post := posts[productId]
+		skusInProduct := postSkus[productId]
+
+		err := applyStatusChanges(post, skusInProduct, changes)
+		if err == nil {
+			_, err = UpdateProduct(productId, post, tenant, c7AppAuthEncoded, retryCount, rl)
+		}
+
+		for _, sku := range skusInProduct {
+			result := &results[resultIdx[sku]]
+			if err != nil {
+				result.Status = BulkStatusFailed
+				result.Err = err
+			} else {
+				result.Status = BulkStatusApplied
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// diffProductChange lists the fields of change that differ from the current
+// product and variant.
+func diffProductChange(product *C7Product, variant *C7ProductVariant, change ProductChange) []string {
+	diffs := []string{}
+	if change.Price != nil && *change.Price != variant.Price {
+		diffs = append(diffs, fmt.Sprintf("price: %d -> %d", variant.Price, *change.Price))
+	}
+	if change.ComparePrice != nil && (variant.ComparePrice == nil || *change.ComparePrice != *variant.ComparePrice) {
+		from := "none"
+		if variant.ComparePrice != nil {
+			from = fmt.Sprint(*variant.ComparePrice)
+		}
+		diffs = append(diffs, fmt.Sprintf("comparePrice: %s -> %d", from, *change.ComparePrice))
+	}
+	if change.AdminStatus != nil && *change.AdminStatus != product.AdminStatus {
+		diffs = append(diffs, fmt.Sprintf("adminStatus: %s -> %s", product.AdminStatus, *change.AdminStatus))
+	}
+	if change.WebStatus != nil && *change.WebStatus != product.WebStatus {
+		diffs = append(diffs, fmt.Sprintf("webStatus: %s -> %s", product.WebStatus, *change.WebStatus))
+	}
+	return diffs
+}
+
+// applyStatusChanges sets the product statuses requested by any of its SKUs,
+// failing if two SKUs of the same product ask for different values.
+func applyStatusChanges(post *ProductPost, skus []string, changes map[string]ProductChange) error {
+	var adminStatus, webStatus *string
+	for _, sku := range skus {
+		change := changes[sku]
+		if change.AdminStatus != nil {
+			if adminStatus != nil && *adminStatus != *change.AdminStatus {
+				return errors.New("conflicting admin status changes for the same product")
+			}
+			adminStatus = change.AdminStatus
+		}
+		if change.WebStatus != nil {
+			if webStatus != nil && *webStatus != *change.WebStatus {
+				return errors.New("conflicting web status changes for the same product")
+			}
+			webStatus = change.WebStatus
+		}
+	}
+	if adminStatus != nil {
+		post.AdminStatus = *adminStatus
+	}
+	if webStatus != nil {
+		post.WebStatus = *webStatus
+	}
+	return nil
+}
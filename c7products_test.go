@@ -0,0 +1,190 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// useTestEndpoints points Endpoints at a test server for the duration of the
+// test, so the endpoint-specific helpers can be exercised offline.
+func useTestEndpoints(t *testing.T, handler http.Handler) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	original := Endpoints
+	Endpoints = GetEndpoints(srv.URL)
+	t.Cleanup(func() {
+		Endpoints = original
+		srv.Close()
+	})
+	return srv
+}
+
+func validProductPost() *ProductPost {
+	return &ProductPost{
+		Title:       "2021 Cabernet",
+		Type:        ProductTypeWine,
+		AdminStatus: AdminStatusAvailable,
+		WebStatus:   WebStatusAvailable,
+		Variants: []ProductVariantPost{
+			{Title: "750ml", Sku: "CAB21-750", Price: 4500, TaxType: TaxTypeWine, HasInventory: true, HasShipping: true},
+		},
+	}
+}
+
+func TestValidateProductPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(p *ProductPost)
+		wantErr bool
+	}{
+		{"valid", func(p *ProductPost) {}, false},
+		{"missing title", func(p *ProductPost) { p.Title = "" }, true},
+		{"bad type", func(p *ProductPost) { p.Type = "Beer" }, true},
+		{"bad admin status", func(p *ProductPost) { p.AdminStatus = "Retired" }, true},
+		{"retired web status", func(p *ProductPost) { p.WebStatus = WebStatusRetired }, false},
+		{"no variants", func(p *ProductPost) { p.Variants = nil }, true},
+		{"bad tax type", func(p *ProductPost) { p.Variants[0].TaxType = "Beer" }, true},
+		{"missing sku", func(p *ProductPost) { p.Variants[0].Sku = "" }, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := validProductPost()
+			tc.mutate(p)
+			if err := ValidateProductPost(p); (err != nil) != tc.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// An invalid product must not cost a request.
+func TestCreateProduct_ValidatesBeforeSending(t *testing.T) {
+	var hits int32
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+
+	p := validProductPost()
+	p.Type = "Beer"
+	if _, err := CreateProduct(p, "t", "a", 0, nil); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if hits != 0 {
+		t.Errorf("made %d requests for an invalid product, want 0", hits)
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	var got ProductPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/product" {
+			t.Errorf("got %s %s, want POST /product", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.Write([]byte(`{"id":"p1","title":"2021 Cabernet","type":"Wine","variants":[{"id":"v1","sku":"CAB21-750","price":4500}]}`))
+	}))
+
+	product, err := CreateProduct(validProductPost(), "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.ID != "p1" || product.VariantBySku("CAB21-750") == nil {
+		t.Errorf("product = %+v", product)
+	}
+	if got.Variants[0].Sku != "CAB21-750" {
+		t.Errorf("posted %+v", got)
+	}
+}
+
+// The search matches loosely, so the SKU must be matched exactly client-side.
+func TestGetProductBySku(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "" {
+			t.Error("expected the sku to be searched for")
+		}
+		w.Write([]byte(`{"total":2,"products":[
+			{"id":"p1","variants":[{"id":"v1","sku":"CAB21-750-MAG"}]},
+			{"id":"p2","variants":[{"id":"v2","sku":"CAB21-375"},{"id":"v3","sku":"CAB21-750"}]}
+		]}`))
+	}))
+
+	product, variant, err := GetProductBySku("cab21-750", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.ID != "p2" || variant.ID != "v3" {
+		t.Errorf("got product %s variant %s, want p2 v3", product.ID, variant.ID)
+	}
+
+	if _, _, err := GetProductBySku("NOPE", "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for an unknown sku")
+	}
+}
+
+func TestBulkUpdateProducts(t *testing.T) {
+	puts := map[string]ProductPost{}
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/product":
+			w.Write([]byte(`{"total":2,"products":[
+				{"id":"p1","title":"Cab","type":"Wine","adminStatus":"Available","webStatus":"Available","variants":[
+					{"id":"v1","sku":"A","price":100,"taxType":"Wine"},
+					{"id":"v2","sku":"B","price":200,"taxType":"Wine"}
+				]},
+				{"id":"p2","title":"Tee","type":"General Merchandise","adminStatus":"Available","webStatus":"Available","variants":[
+					{"id":"v3","sku":"C","price":300,"taxType":"General Merchandise"}
+				]}
+			]}`))
+		case r.Method == http.MethodPut:
+			var post ProductPost
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &post)
+			puts[r.URL.Path] = post
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	price := func(v int) *int { return &v }
+	status := func(s string) *string { return &s }
+
+	results, err := BulkUpdateProducts(map[string]ProductChange{
+		"A": {Price: price(150)},
+		"B": {Price: price(200)},
+		"C": {WebStatus: status(WebStatusNotAvailable)},
+		"D": {Price: price(1)},
+	}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"A": BulkStatusApplied,
+		"B": BulkStatusSkipped,
+		"C": BulkStatusApplied,
+		"D": BulkStatusFailed,
+	}
+	for _, result := range results {
+		if result.Status != want[result.Sku] {
+			t.Errorf("%s: status %s, want %s (err %v)", result.Sku, result.Status, want[result.Sku], result.Err)
+		}
+	}
+
+	if len(puts) != 2 {
+		t.Fatalf("made %d PUTs, want one per changed product: %v", len(puts), puts)
+	}
+	p1 := puts["/product/p1"]
+	if p1.Variants[0].Price != 150 || p1.Variants[1].Price != 200 {
+		t.Errorf("p1 variants = %+v", p1.Variants)
+	}
+	if puts["/product/p2"].WebStatus != WebStatusNotAvailable {
+		t.Errorf("p2 web status = %q", puts["/product/p2"].WebStatus)
+	}
+}
@@ -57,3 +57,10 @@ func GetWineryInfoSettings(tenant string, auth string, rl genericRateLimiter) (*
 	return &settingsPayload.Settings[0], nil
 
 }
+
+// Money returns cents in the winery's currency. Amounts decoded from
+// Commerce7 carry no currency; use this, or WithCurrency with s.Currency, to
+// attach it.
+func (s *WinerySettings) Money(cents int64) Money {
+	return Money{Cents: cents, Currency: s.Currency}
+}
@@ -5,14 +5,33 @@ import (
 )
 
 type FulfillmentAllItems struct {
-	SendTransactionEmail bool      `json:"sendTransactionEmail"`
-	Type                 string    `json:"type"`
-	FulfillmentDate      time.Time `json:"fulfillmentDate"`
-	Shipped              *struct {
-		TrackingNumbers []string `json:"trackingNumbers"`
-		Carrier         string   `json:"carrier"`
-	} `json:"shipped"`
-	PackageCount int `json:"packageCount"`
+	SendTransactionEmail bool                `json:"sendTransactionEmail"`
+	Type                 string              `json:"type"`
+	FulfillmentDate      time.Time           `json:"fulfillmentDate"`
+	Shipped              *FulfillmentShipped `json:"shipped"`
+	PackageCount         int                 `json:"packageCount"`
+}
+
+// FulfillmentPost fulfills specific lines of an order. Build these with
+// NewFulfillmentBuilder, which checks quantities against what is left.
+type FulfillmentPost struct {
+	SendTransactionEmail bool                  `json:"sendTransactionEmail"`
+	Type                 string                `json:"type"` // See OrderFulfillmentType constants
+	FulfillmentDate      time.Time             `json:"fulfillmentDate"`
+	InventoryLocationID  string                `json:"inventoryLocationId,omitempty"`
+	Shipped              *FulfillmentShipped   `json:"shipped,omitempty"`
+	PackageCount         int                   `json:"packageCount,omitempty"`
+	Items                []FulfillmentItemPost `json:"items"`
+}
+
+type FulfillmentShipped struct {
+	TrackingNumbers []string `json:"trackingNumbers"`
+	Carrier         string   `json:"carrier"`
+}
+
+type FulfillmentItemPost struct {
+	OrderItemID string `json:"orderItemId"`
+	Quantity    int    `json:"quantity"`
 }
 
 // Struct to return as response to C7 Order Details Page
@@ -31,3 +50,22 @@ type OrderDetailsStatusCard struct {
 	Title    string `json:"title"`
 	Variant  string `json:"variant,omitempty"` // Can be null, "success", "warning", or "error"
 }
+
+// Address is the full address block Commerce7 uses for bill-to, ship-to and
+// similar fields on orders, carts and club memberships.
+type Address struct {
+	ID                string `json:"id,omitempty"`
+	CustomerAddressID string `json:"customerAddressId,omitempty"`
+	Honorific         string `json:"honorific,omitempty"`
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	Company           string `json:"company,omitempty"`
+	BirthDate         string `json:"birthDate,omitempty"`
+	Phone             string `json:"phone,omitempty"`
+	Address           string `json:"address"`
+	Address2          string `json:"address2,omitempty"`
+	City              string `json:"city"`
+	StateCode         string `json:"stateCode"`
+	ZipCode           string `json:"zipCode"`
+	CountryCode       string `json:"countryCode"`
+}
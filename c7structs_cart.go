@@ -0,0 +1,97 @@
+package c7api
+
+import "time"
+
+// C7Cart is an open cart.
+type C7Cart struct {
+	ID                  string         `json:"id"`
+	CustomerID          string         `json:"customerId"`
+	OrderDeliveryMethod string         `json:"orderDeliveryMethod"`
+	Items               []CartItem     `json:"items"`
+	Coupons             []CartCoupon   `json:"coupons"`
+	ShipTo              *Address       `json:"shipTo"`
+	Shipping            []CartShipping `json:"shipping"`
+	SubTotal            Money          `json:"subTotal"`
+	ShipTotal           Money          `json:"shipTotal"`
+	TaxTotal            Money          `json:"taxTotal"`
+	Total               Money          `json:"total"`
+	CreatedAt           time.Time      `json:"createdAt"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+}
+
+// ItemBySku returns the cart item with the given SKU, or nil.
+func (c *C7Cart) ItemBySku(sku string) *CartItem {
+	for i := range c.Items {
+		if c.Items[i].Sku == sku {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+type CartItem struct {
+	ID                  string `json:"id"`
+	ProductID           string `json:"productId"`
+	ProductVariantID    string `json:"productVariantId"`
+	ProductTitle        string `json:"productTitle"`
+	ProductVariantTitle string `json:"productVariantTitle"`
+	Sku                 string `json:"sku"`
+	Quantity            int    `json:"quantity"`
+	Price               Money  `json:"price"`
+}
+
+type CartCoupon struct {
+	ID         string `json:"id"`
+	CouponID   string `json:"couponId"`
+	Code       string `json:"code"`
+	Title      string `json:"title"`
+	TotalValue Money  `json:"totalValue"`
+}
+
+type CartShipping struct {
+	ShippingServiceID string `json:"shippingServiceId"`
+	Title             string `json:"title"`
+	Carrier           string `json:"carrier"`
+	Price             Money  `json:"price"`
+}
+
+// For creating a new cart
+type CartPost struct {
+	CustomerID          string `json:"customerId"`
+	OrderDeliveryMethod string `json:"orderDeliveryMethod,omitempty"`
+}
+
+// For adding an item to a cart
+type CartItemPost struct {
+	ProductVariantID string `json:"productVariantId"`
+	Quantity         int    `json:"quantity"`
+}
+
+// For changing the quantity of an item already in a cart
+type CartItemPut struct {
+	Quantity int `json:"quantity"`
+}
+
+type CartCouponPost struct {
+	Code string `json:"code"`
+}
+
+// For setting where and how a cart ships. Only the fields set are sent.
+type CartShippingPut struct {
+	OrderDeliveryMethod string   `json:"orderDeliveryMethod,omitempty"`
+	ShipTo              *Address `json:"shipTo,omitempty"`
+	ShippingServiceID   string   `json:"shippingServiceId,omitempty"`
+}
+
+// TenderPost is a single payment applied when placing an order.
+type TenderPost struct {
+	TenderType           string `json:"tenderType"` // See TenderType constants
+	AmountTendered       Money  `json:"amountTendered"`
+	CustomerCreditCardID string `json:"customerCreditCardId,omitempty"` // Credit Card tenders
+	GiftCardCode         string `json:"giftCardCode,omitempty"`         // Gift Card tenders
+	OtherPaymentMethod   string `json:"otherPaymentMethod,omitempty"`   // Other tenders
+}
+
+type CartCheckoutPost struct {
+	Tenders []TenderPost `json:"tenders"`
+}
@@ -0,0 +1,79 @@
+package c7api
+
+import "time"
+
+// C7ClubMemberships is the paged wrapper returned by GET /club-membership.
+type C7ClubMemberships struct {
+	ClubMemberships []C7ClubMembership `json:"clubMemberships"`
+	Total           int                `json:"total"`
+}
+
+func (c C7ClubMemberships) GetItems() []C7ClubMembership { return c.ClubMemberships }
+func (c C7ClubMemberships) GetTotal() int                { return c.Total }
+
+// C7ClubMembership is one customer's membership in one club.
+//
+// Payment and addresses are references to records on the customer, not copies
+// of them; ShipTo is the resolved address as of the last read.
+type C7ClubMembership struct {
+	ID                   string      `json:"id"`
+	CustomerID           string      `json:"customerId"`
+	ClubID               string      `json:"clubId"`
+	Club                 *ProductRef `json:"club"`
+	Status               string      `json:"status"` // See ClubMembershipStatus constants
+	SignupDate           time.Time   `json:"signupDate"`
+	CancelDate           *time.Time  `json:"cancelDate"`
+	CancellationReason   string      `json:"cancellationReason"`
+	CancellationComments string      `json:"cancellationComments"`
+	OnHoldStartDate      *time.Time  `json:"onHoldStartDate"`
+	OnHoldEndDate        *time.Time  `json:"onHoldEndDate"`
+	OrderDeliveryMethod  string      `json:"orderDeliveryMethod"` // See OrderDeliveryMethod constants
+
+	ShipTo                    *Address `json:"shipTo"`
+	ShipToCustomerAddressID   string   `json:"shipToCustomerAddressId"`
+	BillToCustomerAddressID   string   `json:"billToCustomerAddressId"`
+	CustomerCreditCardID      string   `json:"customerCreditCardId"`
+	PickupInventoryLocationID string   `json:"pickupInventoryLocationId"`
+
+	MetaData  map[string]any `json:"metaData"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	Unknown   UnknownFields  `json:"-"`
+}
+
+func (m *C7ClubMembership) UnmarshalJSON(data []byte) error {
+	type alias C7ClubMembership
+	return decodeWithUnknown(data, (*alias)(m), &m.Unknown)
+}
+
+func (m C7ClubMembership) MarshalJSON() ([]byte, error) {
+	type alias C7ClubMembership
+	return encodeWithUnknown(alias(m), m.Unknown)
+}
+
+// ClubMembershipPost is the payload for signing a customer up to a club.
+//
+// Ship memberships need a ShipToCustomerAddressID; pickup memberships need a
+// PickupInventoryLocationID. Leave SignupDate nil to sign up as of now.
+type ClubMembershipPost struct {
+	CustomerID                string         `json:"customerId"`
+	ClubID                    string         `json:"clubId"`
+	SignupDate                *time.Time     `json:"signupDate,omitempty"`
+	OrderDeliveryMethod       string         `json:"orderDeliveryMethod"`
+	ShipToCustomerAddressID   string         `json:"shipToCustomerAddressId,omitempty"`
+	BillToCustomerAddressID   string         `json:"billToCustomerAddressId,omitempty"`
+	CustomerCreditCardID      string         `json:"customerCreditCardId,omitempty"`
+	PickupInventoryLocationID string         `json:"pickupInventoryLocationId,omitempty"`
+	MetaData                  map[string]any `json:"metaData,omitempty"`
+}
+
+// ClubMembershipStatusPut is the payload for a status change. Only the fields
+// relevant to the new status are sent.
+type ClubMembershipStatusPut struct {
+	Status               string     `json:"status"`
+	CancelDate           *time.Time `json:"cancelDate,omitempty"`
+	CancellationReason   string     `json:"cancellationReason,omitempty"`
+	CancellationComments string     `json:"cancellationComments,omitempty"`
+	OnHoldStartDate      *time.Time `json:"onHoldStartDate,omitempty"`
+	OnHoldEndDate        *time.Time `json:"onHoldEndDate,omitempty"`
+}
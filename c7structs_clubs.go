@@ -0,0 +1,91 @@
+package c7api
+
+import "time"
+
+// C7Clubs is the paged wrapper returned by GET /club.
+type C7Clubs struct {
+	Clubs []C7Club `json:"clubs"`
+	Total int      `json:"total"`
+}
+
+func (c C7Clubs) GetItems() []C7Club { return c.Clubs }
+func (c C7Clubs) GetTotal() int      { return c.Total }
+
+// C7Club is a club definition as returned by the club endpoint.
+//
+// Not to be confused with Club, which is a customer's membership summary on
+// CustomerFull.
+type C7Club struct {
+	ID              string              `json:"id"`
+	Title           string              `json:"title"`
+	Slug            string              `json:"slug"`
+	Type            string              `json:"type"`        // See ClubType constants
+	AdminStatus     string              `json:"adminStatus"` // See ClubAdminStatus constants
+	WebStatus       string              `json:"webStatus"`   // See ClubWebStatus constants
+	Content         string              `json:"content"`
+	Teaser          string              `json:"teaser"`
+	Image           string              `json:"image"`
+	SEO             *ProductSEO         `json:"seo"`
+	ShippingOptions ClubShippingOptions `json:"shippingOptions"`
+	MetaData        map[string]any      `json:"metaData"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+	Unknown         UnknownFields       `json:"-"`
+}
+
+func (c *C7Club) UnmarshalJSON(data []byte) error {
+	type alias C7Club
+	return decodeWithUnknown(data, (*alias)(c), &c.Unknown)
+}
+
+func (c C7Club) MarshalJSON() ([]byte, error) {
+	type alias C7Club
+	return encodeWithUnknown(alias(c), c.Unknown)
+}
+
+// ClubShippingOptions controls how members of a club receive their shipments.
+type ClubShippingOptions struct {
+	IsShippingAvailable bool     `json:"isShippingAvailable"`
+	IsPickupAvailable   bool     `json:"isPickupAvailable"`
+	ShippingServiceIDs  []string `json:"shippingServiceIds,omitempty"`
+	// Inventory locations members can pick up from, when pickup is available.
+	PickupInventoryLocationIDs []string `json:"pickupInventoryLocationIds,omitempty"`
+}
+
+// ClubPost is the payload for creating or updating a club.
+type ClubPost struct {
+	Title           string              `json:"title"`
+	Slug            string              `json:"slug,omitempty"`
+	Type            string              `json:"type"`
+	AdminStatus     string              `json:"adminStatus"`
+	WebStatus       string              `json:"webStatus"`
+	Content         string              `json:"content,omitempty"`
+	Teaser          string              `json:"teaser,omitempty"`
+	SEO             *ProductSEO         `json:"seo,omitempty"`
+	ShippingOptions ClubShippingOptions `json:"shippingOptions"`
+	MetaData        map[string]any      `json:"metaData,omitempty"`
+	Unknown         UnknownFields       `json:"-"`
+}
+
+func (c ClubPost) MarshalJSON() ([]byte, error) {
+	type alias ClubPost
+	return encodeWithUnknown(alias(c), c.Unknown)
+}
+
+// ToPost converts a club read from Commerce7 into the payload for writing it
+// back, carrying over what the club has in Unknown.
+func (c *C7Club) ToPost() ClubPost {
+	return ClubPost{
+		Title:           c.Title,
+		Slug:            c.Slug,
+		Type:            c.Type,
+		AdminStatus:     c.AdminStatus,
+		WebStatus:       c.WebStatus,
+		Content:         c.Content,
+		Teaser:          c.Teaser,
+		SEO:             c.SEO,
+		ShippingOptions: c.ShippingOptions,
+		MetaData:        c.MetaData,
+		Unknown:         c.Unknown,
+	}
+}
@@ -53,6 +53,17 @@ type CustomerFull struct {
 	Metadata             map[string]any   `json:"metaData"`
 	Appdata              *string          `json:"appData"`
 	Appsync              *string          `json:"appSync"`
+	Unknown              UnknownFields    `json:"-"`
+}
+
+func (c *CustomerFull) UnmarshalJSON(data []byte) error {
+	type alias CustomerFull
+	return decodeWithUnknown(data, (*alias)(c), &c.Unknown)
+}
+
+func (c CustomerFull) MarshalJSON() ([]byte, error) {
+	type alias CustomerFull
+	return encodeWithUnknown(alias(c), c.Unknown)
 }
 
 type Flag struct {
@@ -69,21 +80,21 @@ type Notification struct {
 }
 
 type OrderInformation struct {
-	CurrentWebCartId        *string        `json:"currentWebCartId"`
-	LastOrderId             string         `json:"lastOrderId"`
-	LastOrderDate           string         `json:"lastOrderDate"`
-	OrderCount              int            `json:"orderCount"`
-	LifetimeValue           int            `json:"lifetimeValue"`
-	LifetimeValueSeedAmount int            `json:"lifetimeValueSeedAmount"`
-	YearlyValue             map[string]int `json:"yearlyValue"`
-	Rank                    int            `json:"rank"`
-	RankTrend               *string        `json:"rankTrend"`
-	GrossProfit             int            `json:"grossProfit"`
-	AcquisitionChannel      *string        `json:"acquisitionChannel"`
-	CurrentClubTitle        *string        `json:"currentClubTitle"`
-	DaysInCurrentClub       *int           `json:"daysInCurrentClub"`
-	DaysInClub              int            `json:"daysInClub"`
-	IsActiveClubMember      bool           `json:"isActiveClubMember"`
+	CurrentWebCartId        *string          `json:"currentWebCartId"`
+	LastOrderId             string           `json:"lastOrderId"`
+	LastOrderDate           string           `json:"lastOrderDate"`
+	OrderCount              int              `json:"orderCount"`
+	LifetimeValue           Money            `json:"lifetimeValue"`
+	LifetimeValueSeedAmount Money            `json:"lifetimeValueSeedAmount"`
+	YearlyValue             map[string]Money `json:"yearlyValue"`
+	Rank                    int              `json:"rank"`
+	RankTrend               *string          `json:"rankTrend"`
+	GrossProfit             Money            `json:"grossProfit"`
+	AcquisitionChannel      *string          `json:"acquisitionChannel"`
+	CurrentClubTitle        *string          `json:"currentClubTitle"`
+	DaysInCurrentClub       *int             `json:"daysInCurrentClub"`
+	DaysInClub              int              `json:"daysInClub"`
+	IsActiveClubMember      bool             `json:"isActiveClubMember"`
 }
 
 type Loyalty struct {
@@ -133,7 +144,7 @@ type WineProperties struct {
 type ProductInner struct {
 	Sku            string          `json:"sku"`
 	Image          string          `json:"image"`
-	Price          int             `json:"price"`
+	Price          Money           `json:"price"`
 	Title          string          `json:"title"`
 	Quantity       int             `json:"quantity"`
 	ProductId      string          `json:"productId"`
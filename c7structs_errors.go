@@ -3,7 +3,10 @@ package c7api
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 // C7Error represents a structured error response from the Commerce7 API.
@@ -27,12 +30,20 @@ import (
 //	        fmt.Printf("Error details: %v\n", errDetail)
 //	    }
 //	}
+//
+// Validation errors can also be read as typed FieldErrors:
+//
+//	for _, fe := range c7Err.FieldErrors() {
+//	    form.SetError(fe.Field, fe.Message)
+//	}
 type C7Error struct {
 	StatusCode int              `json:"statusCode"` // HTTP status code from the API response
 	Type       string           `json:"type"`       // Error type classification from Commerce7
 	Message    string           `json:"message"`    // Human-readable error message from Commerce7
 	Errors     []map[string]any `json:"errors"`     // Additional error details and validation errors
 	Err        error            // Internal error containing the full response body or parsing errors
+
+	Response *ResponseMeta `json:"-"` // Request method, URL, attempts, headers and timing, when a request was made
 }
 
 // Error implements the error interface.
@@ -75,6 +86,54 @@ func (e *C7Error) ErrorSimple() string {
 	return fmt.Sprintf("status code: %d, type: %s, message: %s", e.StatusCode, e.Type, e.Message)
 }
 
+// LogValue implements slog.LogValuer, so logging a C7Error with slog emits
+// nested groups rather than the raw response body as one long string:
+//
+//	slog.Error("syncing order", "err", c7Err)
+//
+// Each entry of Errors becomes its own group, keyed by its position.
+func (e C7Error) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("status", e.StatusCode),
+		slog.String("type", e.Type),
+		slog.String("message", e.Message),
+	}
+
+	if len(e.Errors) > 0 {
+		errAttrs := make([]slog.Attr, 0, len(e.Errors))
+		for i, raw := range e.Errors {
+			// Sort the keys so the same error always logs the same way.
+			keys := make([]string, 0, len(raw))
+			for key := range raw {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fields := make([]slog.Attr, 0, len(raw))
+			for _, key := range keys {
+				fields = append(fields, slog.Any(key, raw[key]))
+			}
+			errAttrs = append(errAttrs, slog.Attr{Key: strconv.Itoa(i), Value: slog.GroupValue(fields...)})
+		}
+		attrs = append(attrs, slog.Attr{Key: "errors", Value: slog.GroupValue(errAttrs...)})
+	}
+
+	if e.Response != nil {
+		request := []any{
+			slog.String("method", e.Response.Method),
+			slog.String("url", e.Response.URL),
+			slog.Int("attempts", e.Response.Attempts),
+		}
+		if e.Response.RequestID != "" {
+			request = append(request, slog.String("requestId", e.Response.RequestID))
+		}
+		request = append(request, slog.Duration("latency", e.Response.Latency))
+		attrs = append(attrs, slog.Group("request", request...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
 // UnmarshalJSON implements custom JSON unmarshaling for C7Error.
 // Handles statusCode as either an integer or string type, converting to int.
 func (e *C7Error) UnmarshalJSON(data []byte) error {
@@ -108,3 +167,135 @@ func (e *C7Error) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+// FieldError is a single validation failure from C7Error.Errors, decoded from
+// one of the shapes Commerce7 is known to send.
+//
+// Commerce7 reports validation errors in two forms. The common one is flat:
+//
+//	{"field": "emails.0.email", "message": "must be a valid email", "value": "bob@"}
+//
+// Some endpoints pass the validator's output through instead, with the field
+// as a path array and the rejected value nested under context:
+//
+//	{"message": "\"sku\" is required", "path": ["items", 0, "sku"], "type": "any.required", "context": {"key": "sku"}}
+//
+// Both decode to the same FieldError, so callers can match on Field without
+// caring which one a given endpoint uses.
+type FieldError struct {
+	Field   string // Dotted path to the field, e.g. "items.0.sku"
+	Message string // Human-readable message from Commerce7
+	Code    string // Machine-readable error code, when Commerce7 sends one
+	Value   any    // The rejected value, when Commerce7 echoes it back
+}
+
+func (f FieldError) Error() string {
+	if f.Field == "" {
+		return f.Message
+	}
+	return f.Field + ": " + f.Message
+}
+
+// FieldErrors returns the entries of Errors that match a known validation
+// error shape. Entries that don't are left out; use UnparsedErrors for those.
+func (e *C7Error) FieldErrors() []FieldError {
+	fieldErrors := []FieldError{}
+	for _, raw := range e.Errors {
+		if fe, ok := parseFieldError(raw); ok {
+			fieldErrors = append(fieldErrors, fe)
+		}
+	}
+	return fieldErrors
+}
+
+// FieldErrorsFor returns the field errors for a single field, matched on the
+// dotted path. Useful for mapping errors back onto a form.
+func (e *C7Error) FieldErrorsFor(field string) []FieldError {
+	matches := []FieldError{}
+	for _, fe := range e.FieldErrors() {
+		if fe.Field == field {
+			matches = append(matches, fe)
+		}
+	}
+	return matches
+}
+
+// UnparsedErrors returns the raw entries of Errors that FieldErrors could not
+// decode, so nothing Commerce7 sent is lost.
+func (e *C7Error) UnparsedErrors() []map[string]any {
+	unparsed := []map[string]any{}
+	for _, raw := range e.Errors {
+		if _, ok := parseFieldError(raw); !ok {
+			unparsed = append(unparsed, raw)
+		}
+	}
+	return unparsed
+}
+
+// parseFieldError decodes one entry of C7Error.Errors. It reports false when
+// the entry has no message or no way to identify the field.
+func parseFieldError(raw map[string]any) (FieldError, bool) {
+	fe := FieldError{}
+
+	message, ok := raw["message"].(string)
+	if !ok || message == "" {
+		return fe, false
+	}
+	fe.Message = message
+
+	switch {
+	case raw["field"] != nil:
+		field, ok := raw["field"].(string)
+		if !ok {
+			return fe, false
+		}
+		fe.Field = field
+	case raw["path"] != nil:
+		field, ok := joinErrorPath(raw["path"])
+		if !ok {
+			return fe, false
+		}
+		fe.Field = field
+	default:
+		return fe, false
+	}
+
+	if code, ok := raw["code"].(string); ok {
+		fe.Code = code
+	} else if code, ok := raw["type"].(string); ok {
+		fe.Code = code
+	}
+
+	if value, ok := raw["value"]; ok {
+		fe.Value = value
+	} else if context, ok := raw["context"].(map[string]any); ok {
+		fe.Value = context["value"]
+	}
+
+	return fe, true
+}
+
+// joinErrorPath flattens a validator path into the dotted form used by the
+// flat error shape. JSON numbers decode as float64, so array indexes arrive
+// that way.
+func joinErrorPath(path any) (string, bool) {
+	switch p := path.(type) {
+	case string:
+		return p, true
+	case []any:
+		parts := make([]string, 0, len(p))
+		for _, part := range p {
+			switch v := part.(type) {
+			case string:
+				parts = append(parts, v)
+			case float64:
+				parts = append(parts, strconv.Itoa(int(v)))
+			default:
+				return "", false
+			}
+		}
+		return strings.Join(parts, "."), true
+	default:
+		return "", false
+	}
+}
@@ -1,9 +1,13 @@
 package c7api
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -118,3 +122,98 @@ func Test_PrintC7ErrorSimple(t *testing.T) {
 	fmt.Println(c7Error.ErrorSimple())
 
 }
+
+func Test_C7ErrorFieldErrors(t *testing.T) {
+	jsonData := `{
+		"statusCode": 422,
+		"type": "validationError",
+		"message": "One or more elements is missing or invalid",
+		"errors": [
+			{
+				"field": "emails.0.email",
+				"message": "must be a valid email",
+				"value": "bob@"
+			},
+			{
+				"message": "\"sku\" is required",
+				"path": ["items", 0, "sku"],
+				"type": "any.required",
+				"context": {"key": "sku", "value": null}
+			},
+			{
+				"code": "something-new"
+			}
+		]
+	}`
+
+	c7Error := C7Error{}
+	if err := json.Unmarshal([]byte(jsonData), &c7Error); err != nil {
+		t.Fatal("Error parsing JSON:", err)
+	}
+
+	got := c7Error.FieldErrors()
+	if len(got) != 2 {
+		t.Fatalf("got %d field errors, want 2: %+v", len(got), got)
+	}
+
+	if got[0].Field != "emails.0.email" || got[0].Message != "must be a valid email" || got[0].Value != "bob@" {
+		t.Errorf("flat shape decoded as %+v", got[0])
+	}
+	if got[1].Field != "items.0.sku" || got[1].Code != "any.required" || got[1].Value != nil {
+		t.Errorf("path shape decoded as %+v", got[1])
+	}
+
+	if matches := c7Error.FieldErrorsFor("items.0.sku"); len(matches) != 1 {
+		t.Errorf("FieldErrorsFor returned %d matches, want 1", len(matches))
+	}
+
+	unparsed := c7Error.UnparsedErrors()
+	if len(unparsed) != 1 || unparsed[0]["code"] != "something-new" {
+		t.Errorf("UnparsedErrors = %v, want the unknown entry kept raw", unparsed)
+	}
+}
+
+func Test_C7ErrorLogValue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"statusCode":422,"type":"validationError","message":"invalid","errors":[{"field":"sku","message":"is required"}]}`))
+	}))
+	defer srv.Close()
+
+	_, err := RequestWithRetryAndRead(http.MethodPost, srv.URL, map[string]string{"q": "1"}, nil, "t", "a", 3, nil)
+
+	var c7Err *C7Error
+	if !errors.As(err, &c7Err) {
+		t.Fatalf("err = %T (%v), want *C7Error", err, err)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("request failed", "err", c7Err)
+
+	var logged struct {
+		Err struct {
+			Status  int                          `json:"status"`
+			Type    string                       `json:"type"`
+			Errors  map[string]map[string]string `json:"errors"`
+			Request struct {
+				Method   string `json:"method"`
+				URL      string `json:"url"`
+				Attempts int    `json:"attempts"`
+			} `json:"request"`
+		} `json:"err"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &logged); err != nil {
+		t.Fatalf("log line is not JSON: %v\n%s", err, buf.String())
+	}
+
+	if logged.Err.Status != 422 || logged.Err.Type != "validationError" {
+		t.Errorf("logged %+v", logged.Err)
+	}
+	if logged.Err.Errors["0"]["field"] != "sku" {
+		t.Errorf("errors not logged as nested groups: %s", buf.String())
+	}
+	if logged.Err.Request.Method != http.MethodPost || logged.Err.Request.URL != srv.URL+"?q=1" || logged.Err.Request.Attempts != 1 {
+		t.Errorf("request = %+v", logged.Err.Request)
+	}
+}
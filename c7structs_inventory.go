@@ -0,0 +1,74 @@
+package c7api
+
+import "time"
+
+// InventoryLevels is the paged wrapper returned by GET /inventory.
+type InventoryLevels struct {
+	Inventory []InventoryLevel `json:"inventory"`
+	Total     int              `json:"total"`
+}
+
+func (i InventoryLevels) GetItems() []InventoryLevel { return i.Inventory }
+func (i InventoryLevels) GetTotal() int              { return i.Total }
+
+// InventoryLevel is the stock of one SKU at one inventory location.
+type InventoryLevel struct {
+	ID                    string    `json:"id"`
+	Sku                   string    `json:"sku"`
+	ProductID             string    `json:"productId"`
+	ProductVariantID      string    `json:"productVariantId"`
+	InventoryLocationID   string    `json:"inventoryLocationId"`
+	AvailableForSaleCount int       `json:"availableForSaleCount"`
+	ReserveCount          int       `json:"reserveCount"`
+	AllocatedCount        int       `json:"allocatedCount"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// OnHandCount is the physical stock at the location: what is available for
+// sale plus what is held in reserve or allocated to orders.
+func (i InventoryLevel) OnHandCount() int {
+	return i.AvailableForSaleCount + i.ReserveCount + i.AllocatedCount
+}
+
+// InventoryLocations is the wrapper returned by GET /inventory-location.
+type InventoryLocations struct {
+	InventoryLocations []InventoryLocation `json:"inventoryLocations"`
+	Total              int                 `json:"total"`
+}
+
+func (i InventoryLocations) GetItems() []InventoryLocation { return i.InventoryLocations }
+func (i InventoryLocations) GetTotal() int                 { return i.Total }
+
+type InventoryLocation struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Code      string `json:"code"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// InventoryTransactionPost is the payload for a single inventory movement.
+//
+// Quantity is signed for adjustments and positive for receipts and transfers.
+// ToInventoryLocationID is only used by transfers.
+type InventoryTransactionPost struct {
+	Sku                   string `json:"sku"`
+	InventoryLocationID   string `json:"inventoryLocationId"`
+	ToInventoryLocationID string `json:"toInventoryLocationId,omitempty"`
+	TransactionType       string `json:"transactionType"` // See InventoryTransactionType constants
+	Reason                string `json:"reason"`          // See InventoryReason constants
+	Quantity              int    `json:"quantity"`
+	Notes                 string `json:"notes,omitempty"`
+}
+
+// InventoryTransaction is a recorded inventory movement.
+type InventoryTransaction struct {
+	ID                    string    `json:"id"`
+	Sku                   string    `json:"sku"`
+	InventoryLocationID   string    `json:"inventoryLocationId"`
+	ToInventoryLocationID string    `json:"toInventoryLocationId"`
+	TransactionType       string    `json:"transactionType"`
+	Reason                string    `json:"reason"`
+	Quantity              int       `json:"quantity"`
+	Notes                 string    `json:"notes"`
+	CreatedAt             time.Time `json:"createdAt"`
+}
@@ -19,6 +19,9 @@ type C7Orders struct {
 	Total  int       `json:"total"`
 }
 
+func (o C7Orders) GetItems() []C7Order { return o.Orders }
+func (o C7Orders) GetTotal() int       { return o.Total }
+
 type C7Order_OrderNumberOnly struct {
 	ID          string `json:"id"`
 	OrderNumber int    `json:"orderNumber"`
@@ -28,406 +31,448 @@ type C7Order struct {
 	//AdditionalData any `json:"additionalData"`
 	//AppData        any `json:"appData"`
 	//AppSync        any `json:"appSync"`
-	BillTo struct {
-		Address           string `json:"address"`
-		Address2          string `json:"address2"`
-		BirthDate         string `json:"birthDate"`
-		City              string `json:"city"`
-		Company           string `json:"company"`
-		CountryCode       string `json:"countryCode"`
-		CustomerAddressID string `json:"customerAddressId"`
-		FirstName         string `json:"firstName"`
-		Honorific         string `json:"honorific"`
-		ID                string `json:"id"`
-		LastName          string `json:"lastName"`
-		Phone             string `json:"phone"`
-		StateCode         string `json:"stateCode"`
-		ZipCode           string `json:"zipCode"`
-	} `json:"billTo"`
-	//BottleDepositTotal    int    `json:"bottleDepositTotal"`
-	//CarrierPickupLocation string `json:"carrierPickupLocation"`
-	//CarryOut struct {
-	//  ID string `json:"id"`
-	// 	InventoryLocationID string `json:"inventoryLocationId"`
-	// 	customerAddressId string `json:"customerAddressId"`
-	// 	BirthDate string `json:"birthDate"`
-	// 	Honorific string `json:"honorific"`
-	// 	FirstName string `json:"firstName"`
-	// 	LastName string `json:"lastName"`
-	// 	Company string `json:"company"`
-	// 	Phone string `json:"phone"`
-	// 	Address string `json:"address"`
-	// 	Address2 string `json:"address2"`
-	// 	City string `json:"city"`
-	// 	StateCode string `json:"stateCode"`
-	// 	ZipCode string `json:"zipCode"`
-	// 	CountryCode string `json:"countryCode"`
-	//} `json:"carryOut"`
-	//CartID                string `json:"cartId"`
-	Channel string `json:"channel"`
-	Club    *struct {
-		//ClubID              string `json:"clubId"`
-		//ClubPackageID       string `json:"clubPackageId"`
-		ClubPackageTitle string `json:"clubPackageTitle"`
-		ClubTitle        string `json:"clubTitle"`
-		//ID                  string `json:"id"`
-		ShipmentBuildStatus string `json:"shipmentBuildStatus"`
-	} `json:"club"`
-	//ComplianceStatus string `json:"complianceStatus"`
-	// ConnectionInformation struct {
-	// 	CustomerIpAddress string `json:"customerIpAddress"`
-	// 	UserAgent         string `json:"userAgent"`
-	// } `json:"connectionInformation"`
-	// Coupons []struct {
-	// 	Code          string `json:"code"`
-	// 	CouponID      string `json:"couponId"`
-	// 	ID            string `json:"id"`
-	// 	InUse         bool   `json:"inUse"`
-	// 	ProductValue  int    `json:"productValue"`
-	// 	ShippingValue int    `json:"shippingValue"`
-	// 	Title         string `json:"title"`
-	// 	TotalValue    int    `json:"totalValue"`
-	// } `json:"coupons"`
-	//CreatedAt time.Time `json:"createdAt"`
-	Customer struct {
-		//AppData   string `json:"appData"`
-		//AppSync   string `json:"appSync"`
-		//Avatar    string `json:"avatar"`
-		BirthDate string `json:"birthDate"`
-		City      string `json:"city"`
-		Clubs     []struct {
-			CancelDate       time.Time `json:"cancelDate"`
-			ClubID           string    `json:"clubId"`
-			ClubMembershipID string    `json:"clubMembershipId"`
-			ClubTitle        string    `json:"clubTitle"`
-			SignupDate       time.Time `json:"signupDate"`
-		} `json:"clubs"`
-		CountryCode string    `json:"countryCode"`
-		CreatedAt   time.Time `json:"createdAt"`
-		//EmailMarketingStatus string    `json:"emailMarketingStatus"`
-		Emails []struct {
-			Email  string `json:"email"`
-			ID     string `json:"id"`
-			Status string `json:"status"`
-		} `json:"emails"`
-		Honorific string `json:"honorific"`
-		FirstName string `json:"firstName"`
-		LastName  string `json:"lastName"`
-		ID        string `json:"id"`
-		Flags     []struct {
-			//ID      string `json:"id"`
-			Content string `json:"content"`
-		} `json:"flags"`
-		//HasAccount       bool      `json:"hasAccount"`
-		// LastActivityDate time.Time `json:"lastActivityDate"`
-		// LoginActivity    struct {
-		// 	LastLoginAt  time.Time `json:"lastLoginAt"`
-		// 	LastLogoutAt time.Time `json:"lastLogoutAt"`
-		// 	LoginIp      string    `json:"loginIP"`
-		// } `json:"loginActivity"`
-		MetaData map[string]interface{} `json:"metaData"`
-		//Notifications    []any  `json:"notifications"`
-		OrderInformation struct {
-			// AcquisitionChannel string `json:"acquisitionChannel"`
-			CurrentClubTitle string `json:"currentClubTitle"`
-			// CurrentWebCartID        string    `json:"currentWebCartId"`
-			// DaysInClub              int       `json:"daysInClub"`
-			// DaysInCurrentClub       int       `json:"daysInCurrentClub"`
-			// GrossProfit             int       `json:"grossProfit"`
-			// LastOrderDate           time.Time `json:"lastOrderDate"`
-			// LastOrderID             string    `json:"lastOrderId"`
-			// LifetimeValue           int       `json:"lifetimeValue"`
-			// LifetimeValueSeedAmount int       `json:"lifetimeValueSeedAmount"`
-			// OrderCount              int       `json:"orderCount"`
-			// Rank                    int       `json:"rank"`
-			// RankTrend               string    `json:"rankTrend"`
-			// YearlyValue             struct {
-			// 	Y2023 int `json:"2023"`
-			// } `json:"yearlyValue"`
-		} `json:"orderInformation"`
-		Phones []struct {
-			ID    string `json:"id"`
-			Phone string `json:"phone"`
-		} `json:"phones"`
-		Products []struct {
-			Product struct {
-				Image     string `json:"image"`
-				Price     int    `json:"price"`
-				ProductID string `json:"productId"`
-				Quantity  int    `json:"quantity"`
-				Sku       string `json:"sku"`
-				Title     string `json:"title"`
-				// WineProperties struct {
-				// 	Appellation string `json:"appellation"`
-				// 	CountryCode string `json:"countryCode"`
-				// 	Region      string `json:"region"`
-				// 	Type        string `json:"type"`
-				// 	Varietal    string `json:"varietal"`
-				// 	Vintage     int    `json:"vintage"`
-				// } `json:"wineProperties,omitempty"`
-			} `json:"product"`
-			PurchaseDate time.Time `json:"purchaseDate"`
-		} `json:"products"`
-		StateCode string    `json:"stateCode"`
-		UpdatedAt time.Time `json:"updatedAt"`
-		ZipCode   string    `json:"zipCode"`
-	} `json:"customer"`
-	CustomerID string `json:"customerId"`
+	BillTo                Address                     `json:"billTo"`
+	BottleDepositTotal    Money                       `json:"bottleDepositTotal"`
+	CarrierPickupLocation string                      `json:"carrierPickupLocation"`
+	CarryOut              *OrderPickupAddress         `json:"carryOut"`
+	CartID                string                      `json:"cartId"`
+	Channel               string                      `json:"channel"`
+	Club                  *OrderClub                  `json:"club"`
+	ComplianceStatus      string                      `json:"complianceStatus"`
+	ConnectionInformation *OrderConnectionInformation `json:"connectionInformation"`
+	Coupons               []OrderCoupon               `json:"coupons"`
+	CreatedAt             time.Time                   `json:"createdAt"`
+	Customer              OrderCustomer               `json:"customer"`
+	CustomerID            string                      `json:"customerId"`
 	//Duties     []any  `json:"duties"`
-	//DutyTotal int `json:"dutyTotal"`
-	Flags []struct {
-		//ID      string `json:"id"`
-		Content string `json:"content"`
-	} `json:"flags"`
-	// Fraud struct {
-	// 	Comment      string `json:"comment"`
-	// 	IsFraudulent bool   `json:"isFraudulent"`
-	// } `json:"fraud"`
-	//FraudCheckStatus  string `json:"fraudCheckStatus"`
-	FulfillmentStatus string `json:"fulfillmentStatus"`
-	Fulfillments      []C7OrderFulfillment
-	GiftMessage       string `json:"giftMessage"`
-	ID                string `json:"id"`
-	//IsNoDuty     bool   `json:"isNoDuty"`
-	//IsNonTaxable bool   `json:"isNonTaxable"`
-	Items []struct {
-		AllocationID  string `json:"allocationId"`
-		BottleDeposit int    `json:"bottleDeposit"`
-		BundleItems   []struct {
-			//AllocationID               string   `json:"allocationId"`
-			//BottleDeposit              int      `json:"bottleDeposit"`
-			//CollectionIds              []string `json:"collectionIds"`
-			//ComparePrice               int      `json:"comparePrice"`
-			//CostOfGood                 int      `json:"costOfGood"`
-			//DepartmentCode             string   `json:"departmentCode"`
-			//DepartmentID               string   `json:"departmentId"`
-			//IsOverrideOperatingRegions bool     `json:"isOverrideOperatingRegions"`
-			//IsPriceOverride     bool    `json:"isPriceOverride"`
-			//OriginalPrice       float64 `json:"originalPrice"`
-			//ProductSlug                string   `json:"productSlug"`
-			//Vendor              string  `json:"vendor"`
-			HasInventory        bool    `json:"hasInventory"`
-			HasShipping         bool    `json:"hasShipping"`
-			Image               string  `json:"image"`
-			InventoryLocationID string  `json:"inventoryLocationId"`
-			Price               float64 `json:"price"`
-			ProductID           string  `json:"productId"`
-			ProductTitle        string  `json:"productTitle"`
-			ProductVariantID    string  `json:"productVariantId"`
-			ProductVariantTitle string  `json:"productVariantTitle"`
-			Quantity            int     `json:"quantity"`
-			Sku                 string  `json:"sku"`
-			Tax                 float64 `json:"tax"`
-			TaxType             string  `json:"taxType"`
-			Type                string  `json:"type"`
-			VolumeInMl          int     `json:"volumeInML"`
-			Weight              float64 `json:"weight"`
-		} `json:"bundleItems"`
-		//CollectionIds       string `json:"collectionIds"`
-		//ComparePrice        int    `json:"comparePrice"`
-		//CostOfGood 		  int `json:"costOfGood"`
-		//DepartmentCode      string `json:"departmentCode"`
-		//DepartmentID        string `json:"departmentId"`
-		//IsPriceOverride     bool     `json:"isPriceOverride"`
-		//ItemData            string   `json:"itemData"`
-		//Modifiers           string   `json:"modifiers"`
-		//Notes               []string `json:"notes"`
-		//OriginalPrice       int     `json:"originalPrice"`
-		//ProductSlug         string  `json:"productSlug"`
-		//QuantitySentToPrep  int     `json:"quantitySentToPrep"`
-		//TaxType             string  `json:"taxType"`
-		//Type                string  `json:"type"`
-		//Vendor              string  `json:"vendor"`
-		HasShipping         bool    `json:"hasShipping"`
-		ID                  string  `json:"id"`
-		Image               string  `json:"image"`
-		InventoryLocationID string  `json:"inventoryLocationId"`
-		Price               float64 `json:"price"`
-		ProductID           string  `json:"productId"`
-		ProductTitle        string  `json:"productTitle"`
-		ProductVariantID    string  `json:"productVariantId"`
-		ProductVariantTitle string  `json:"productVariantTitle"`
-		PurchaseType        string  `json:"purchaseType"`
-		Quantity            int     `json:"quantity"`
-		QuantityFulfilled   int     `json:"quantityFulfilled"`
-		Sku                 string  `json:"sku"`
-		Tax                 int     `json:"tax"`
-		VolumeInMl          int     `json:"volumeInML"`
-		Weight              float64 `json:"weight"`
-	} `json:"items"`
-	// LinkedOrders *[]struct {
-	// 	OrderID      string `json:"orderId"`
-	// 	OrderNumber  int    `json:"orderNumber"`
-	// 	PurchaseType string `json:"purchaseType"`
-	// } `json:"linkedOrders"`
-	//LoyaltyPointsEarned int       `json:"loyaltyPointsEarned"`
-	//OrderFulfilledDate  time.Time `json:"orderFulfilledDate"`
+	DutyTotal           Money                `json:"dutyTotal"`
+	Flags               []Flag               `json:"flags"`
+	Fraud               *OrderFraud          `json:"fraud"`
+	FraudCheckStatus    string               `json:"fraudCheckStatus"`
+	FulfillmentStatus   string               `json:"fulfillmentStatus"`
+	Fulfillments        []C7OrderFulfillment `json:"fulfillments"`
+	GiftMessage         string               `json:"giftMessage"`
+	ID                  string               `json:"id"`
+	IsNoDuty            bool                 `json:"isNoDuty"`
+	IsNonTaxable        bool                 `json:"isNonTaxable"`
+	Items               []OrderItem          `json:"items"`
+	LinkedOrders        []OrderLink          `json:"linkedOrders"`
+	LoyaltyPointsEarned int                  `json:"loyaltyPointsEarned"`
 	//MetaData            string    `json:"metaData"`
-	//OrderSource         string    `json:"orderSource"`
-	OrderDeliveryMethod string    `json:"orderDeliveryMethod"`
-	OrderNumber         int       `json:"orderNumber"`
-	OrderSubmittedDate  time.Time `json:"orderSubmittedDate"`
-	OrderPaidDate       time.Time `json:"orderPaidDate"`
-	OrderTags           []struct {
-		AppliesToCondition string    `json:"appliesToCondition"`
-		CreatedAt          time.Time `json:"createdAt"`
-		ID                 string    `json:"id"`
-		ObjectType         string    `json:"objectType"`
-		Title              string    `json:"title"`
-		Type               string    `json:"type"`
-		UpdatedAt          time.Time `json:"updatedAt"`
-	} `json:"orderTags"`
-	PaymentStatus string `json:"paymentStatus"`
-	// PickupBy      struct {
-	// 	Id                  string `json:"id"`
-	// 	City                string `json:"city"`
-	// 	Phone               string `json:"phone"`
-	// 	Address             string `json:"address"`
-	// 	Company             string `json:"company"`
-	// 	ZipCode             string `json:"zipCode"`
-	// 	Address2            string `json:"address2"`
-	// 	FirstName           string `json:"firstName"`
-	// 	LastName            string `json:"lastName"`
-	// 	Honorific           string `json:"honorific"`
-	// 	StateCode           string `json:"stateCode"`
-	// 	CountryCode         string `json:"countryCode"`
-	// 	CustomerAddressID   string `json:"customerAddressId"`
-	// 	InventoryLocationID string `json:"inventoryLocationId"`
-	// } `json:"pickupBy"`
-	//PosProfile          string   `json:"posProfile"`
-	//PosProfileID        string   `json:"posProfileId"`
-	//PreviousOrderID     string   `json:"previousOrderId"`
-	//PreviousOrderNumber int      `json:"previousOrderNumber"`
-	//Promotions        []string `json:"promotions"`
-	PurchaseType      string `json:"purchaseType"`
-	RefundOrderID     string `json:"refundOrderId"`
-	RefundOrderNumber int    `json:"refundOrderNumber"`
+	OrderDeliveryMethod string              `json:"orderDeliveryMethod"`
+	OrderFulfilledDate  *time.Time          `json:"orderFulfilledDate"`
+	OrderNumber         int                 `json:"orderNumber"`
+	OrderSource         string              `json:"orderSource"`
+	OrderSubmittedDate  time.Time           `json:"orderSubmittedDate"`
+	OrderPaidDate       time.Time           `json:"orderPaidDate"`
+	OrderTags           []OrderTag          `json:"orderTags"`
+	PaymentStatus       string              `json:"paymentStatus"`
+	PickupBy            *OrderPickupAddress `json:"pickupBy"`
+	PosProfileID        string              `json:"posProfileId"`
+	PreviousOrderID     string              `json:"previousOrderId"`
+	PreviousOrderNumber int                 `json:"previousOrderNumber"`
+	Promotions          []OrderPromotion    `json:"promotions"`
+	PurchaseType        string              `json:"purchaseType"`
+	RefundOrderID       string              `json:"refundOrderId"`
+	RefundOrderNumber   int                 `json:"refundOrderNumber"`
 	//Reservation       string   `json:"reservation"`
-	SalesAttributeCode string `json:"salesAttributeCode,omitempty"`
-	// SalesAssociate    struct {
-	// 	AccountID string `json:"accountId"`
-	// 	Name      string `json:"name"`
-	// } `json:"salesAssociate"`
-	SelectedShippingOptions struct {
-		RequestedShipDate string `json:"requestedShipDate,omitempty"`
-		//ShippingServiceID string `json:"shippingServiceId"`
-	} `json:"selectedShippingOptions"`
-	ShipTo struct {
-		//CustomerAddressID string `json:"customerAddressId"`
-		//BirthDate   string `json:"birthDate"`
-		//ID          string `json:"id"`
-		Address     string `json:"address"`
-		Address2    string `json:"address2"`
-		City        string `json:"city"`
-		Company     string `json:"company"`
-		CountryCode string `json:"countryCode"`
-		FirstName   string `json:"firstName"`
-		Honorific   string `json:"honorific"`
-		LastName    string `json:"lastName"`
-		Phone       string `json:"phone"`
-		StateCode   string `json:"stateCode"`
-		ZipCode     string `json:"zipCode"`
-	} `json:"shipTo"`
-	ShipTotal int `json:"shipTotal"`
-	Shipping  []struct {
-		Carrier           string `json:"carrier"`
-		Code              string `json:"code"`
-		Price             int    `json:"price"`
-		Service           string `json:"service"`
-		ShippingServiceID string `json:"shippingServiceId"`
-		Tax               int    `json:"tax"`
-		Title             string `json:"title"`
-		//ID      string `json:"id"`
-		//OriginalPrice     int    `json:"originalPrice"`
-		//ProcessorResponse string `json:"processorResponse"`
-		//Vendor            string `json:"vendor"`
-	} `json:"shipping"`
-	ShippingInstructions string `json:"shippingInstructions"`
-	ShippingStatus       string `json:"shippingStatus"`
-	SubTotal             int    `json:"subTotal"`
-	Tags                 []struct {
-		Title string `json:"title"`
-		Type  string `json:"type"`
-		//AppliesToCondition string `json:"appliesToCondition"`
-		//ID                 string `json:"id"`
-		//ObjectType         string    `json:"objectType"`
-		//CreatedAt          time.Time `json:"createdAt"`
-		//UpdatedAt          time.Time `json:"updatedAt"`
-	} `json:"tags"`
-	TaxSaleType string `json:"taxSaleType"`
-	TaxTotal    int    `json:"taxTotal"`
-	// Taxes       []struct {
-	// 	Cannabis           int     `json:"cannabis"`
-	// 	CountryCode        string  `json:"countryCode"`
-	// 	Food               int     `json:"food"`
-	// 	Freight            int     `json:"freight"`
-	// 	GeneralMerchandise float64 `json:"generalMerchandise"`
-	// 	ID                 string  `json:"id"`
-	// 	IsFlatRate         bool    `json:"isFlatRate"`
-	// 	IsIncludedInPrice  bool    `json:"isIncludedInPrice"`
-	// 	IsNonTaxable       bool    `json:"isNonTaxable"`
-	// 	Price              int     `json:"price"`
-	// 	SortOrder          int     `json:"sortOrder"`
-	// 	StateCode          string  `json:"stateCode"`
-	// 	Title              string  `json:"title"`
-	// 	Vendor             string  `json:"vendor"`
-	// 	Wine               float64 `json:"wine"`
-	// } `json:"taxes"`
-	Tenders []struct {
-		AmountTendered int       `json:"amountTendered"`
-		ChargeStatus   string    `json:"chargeStatus"`
-		ChargeType     string    `json:"chargeType"`
-		CreatedAt      time.Time `json:"createdAt"`
-		// CreditCard     struct {
-		// 	AuthorizationID      string `json:"authorizationId"`
-		// 	Bin                  string `json:"bin"`
-		// 	CardBrand            string `json:"cardBrand"`
-		// 	CardHolderName       string `json:"cardHolderName"`
-		// 	CustomerCreditCardID string `json:"customerCreditCardId"`
-		// 	ExpiryMo             int    `json:"expiryMo"`
-		// 	ExpiryYr             int    `json:"expiryYr"`
-		// 	Gateway              string `json:"gateway"`
-		// 	MaskedCardNumber     string `json:"maskedCardNumber"`
-		// 	OneTimeToken         string `json:"oneTimeToken"`
-		// 	ProcessorResponse    string `json:"processorResponse"`
-		// 	TokenOnFile          string `json:"tokenOnFile"`
-		// } `json:"creditCard"`
-		// ErrorCode string `json:"errorCode"`
-		// GiftCard struct {
-		// 	ID            string `json:"id"`
-		// 	MaskedCode    string `json:"maskedCode"`
-		// 	TransactionID string `json:"transactionId"`
-		// } `json:"giftCard"`
-		//ID string `json:"id"`
-		// Loyalty struct {
-		// 	Points        int    `json:"points"`
-		// 	TransactionID string `json:"transactionId"`
-		// } `json:"loyalty"`
-		// OtherPaymentMethod string    `json:"otherPaymentMethod"`
-		// PaymentDate        time.Time `json:"paymentDate"`
-		// PreviousTenderID   string    `json:"previousTenderId"`
-		TenderType string `json:"tenderType"`
-		// Tip        int    `json:"tip"`
-		// UpdatedAt  time.Time `json:"updatedAt"`
-	} `json:"tenders"`
-	TipTotal      int       `json:"tipTotal"`
-	Total         int       `json:"total"`
-	TotalAfterTip int       `json:"totalAfterTip"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	SalesAttributeCode      string                       `json:"salesAttributeCode,omitempty"`
+	SalesAssociate          *OrderSalesAssociate         `json:"salesAssociate"`
+	SelectedShippingOptions OrderSelectedShippingOptions `json:"selectedShippingOptions"`
+	ShipTo                  Address                      `json:"shipTo"`
+	ShipTotal               Money                        `json:"shipTotal"`
+	Shipping                []OrderShipping              `json:"shipping"`
+	ShippingInstructions    string                       `json:"shippingInstructions"`
+	ShippingStatus          string                       `json:"shippingStatus"`
+	SubTotal                Money                        `json:"subTotal"`
+	Tags                    []OrderTag                   `json:"tags"`
+	TaxSaleType             string                       `json:"taxSaleType"`
+	TaxTotal                Money                        `json:"taxTotal"`
+	Taxes                   []OrderTax                   `json:"taxes"`
+	Tenders                 []OrderTender                `json:"tenders"`
+	TipTotal                Money                        `json:"tipTotal"`
+	Total                   Money                        `json:"total"`
+	TotalAfterTip           Money                        `json:"totalAfterTip"`
+	UpdatedAt               time.Time                    `json:"updatedAt"`
+	Unknown                 UnknownFields                `json:"-"`
+}
+
+func (o *C7Order) UnmarshalJSON(data []byte) error {
+	type alias C7Order
+	return decodeWithUnknown(data, (*alias)(o), &o.Unknown)
+}
+
+func (o C7Order) MarshalJSON() ([]byte, error) {
+	type alias C7Order
+	return encodeWithUnknown(alias(o), o.Unknown)
+}
+
+// OrderPickupAddress is who collects a Pickup or Carry Out order, and from
+// where.
+type OrderPickupAddress struct {
+	Address
+	InventoryLocationID string `json:"inventoryLocationId"`
+}
+
+type OrderClub struct {
+	ID                  string `json:"id"`
+	ClubID              string `json:"clubId"`
+	ClubPackageID       string `json:"clubPackageId"`
+	ClubPackageTitle    string `json:"clubPackageTitle"`
+	ClubTitle           string `json:"clubTitle"`
+	ShipmentBuildStatus string `json:"shipmentBuildStatus"`
+}
+
+type OrderConnectionInformation struct {
+	CustomerIpAddress string `json:"customerIpAddress"`
+	UserAgent         string `json:"userAgent"`
+}
+
+type OrderCoupon struct {
+	ID            string `json:"id"`
+	CouponID      string `json:"couponId"`
+	Code          string `json:"code"`
+	Title         string `json:"title"`
+	InUse         bool   `json:"inUse"`
+	ProductValue  Money  `json:"productValue"`
+	ShippingValue Money  `json:"shippingValue"`
+	TotalValue    Money  `json:"totalValue"`
+}
+
+type OrderPromotion struct {
+	ID            string `json:"id"`
+	PromotionID   string `json:"promotionId"`
+	Title         string `json:"title"`
+	ProductValue  Money  `json:"productValue"`
+	ShippingValue Money  `json:"shippingValue"`
+	TotalValue    Money  `json:"totalValue"`
+}
+
+// OrderCustomer is the snapshot of the customer embedded in an order.
+type OrderCustomer struct {
+	//AppData   string `json:"appData"`
+	//AppSync   string `json:"appSync"`
+	//Avatar    string `json:"avatar"`
+	BirthDate   string               `json:"birthDate"`
+	City        string               `json:"city"`
+	Clubs       []OrderCustomerClub  `json:"clubs"`
+	CountryCode string               `json:"countryCode"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	Emails      []OrderCustomerEmail `json:"emails"`
+	//EmailMarketingStatus string    `json:"emailMarketingStatus"`
+	Honorific string `json:"honorific"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	ID        string `json:"id"`
+	Flags     []Flag `json:"flags"`
+	//HasAccount       bool      `json:"hasAccount"`
+	MetaData map[string]interface{} `json:"metaData"`
+	//Notifications    []any  `json:"notifications"`
+	OrderInformation OrderCustomerOrderInformation `json:"orderInformation"`
+	Phones           []OrderCustomerPhone          `json:"phones"`
+	Products         []OrderCustomerProduct        `json:"products"`
+	StateCode        string                        `json:"stateCode"`
+	UpdatedAt        time.Time                     `json:"updatedAt"`
+	ZipCode          string                        `json:"zipCode"`
+}
+
+type OrderCustomerClub struct {
+	CancelDate       time.Time `json:"cancelDate"`
+	ClubID           string    `json:"clubId"`
+	ClubMembershipID string    `json:"clubMembershipId"`
+	ClubTitle        string    `json:"clubTitle"`
+	SignupDate       time.Time `json:"signupDate"`
+}
+
+type OrderCustomerEmail struct {
+	ID     string `json:"id"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+type OrderCustomerPhone struct {
+	ID    string `json:"id"`
+	Phone string `json:"phone"`
+}
+
+type OrderCustomerOrderInformation struct {
+	// AcquisitionChannel string `json:"acquisitionChannel"`
+	CurrentClubTitle string `json:"currentClubTitle"`
+	// LastOrderDate           time.Time `json:"lastOrderDate"`
+	// LastOrderID             string    `json:"lastOrderId"`
+	// LifetimeValue           int       `json:"lifetimeValue"`
+	// OrderCount              int       `json:"orderCount"`
+}
+
+type OrderCustomerProduct struct {
+	Product      OrderCustomerProductDetail `json:"product"`
+	PurchaseDate time.Time                  `json:"purchaseDate"`
+}
+
+type OrderCustomerProductDetail struct {
+	Image          string          `json:"image"`
+	Price          Money           `json:"price"`
+	ProductID      string          `json:"productId"`
+	Quantity       int             `json:"quantity"`
+	Sku            string          `json:"sku"`
+	Title          string          `json:"title"`
+	WineProperties *WineProperties `json:"wineProperties,omitempty"`
+}
+
+type OrderFraud struct {
+	Comment      string `json:"comment"`
+	IsFraudulent bool   `json:"isFraudulent"`
+}
+
+type OrderItem struct {
+	AllocationID    string            `json:"allocationId"`
+	BottleDeposit   Money             `json:"bottleDeposit"`
+	BundleItems     []OrderBundleItem `json:"bundleItems"`
+	CollectionIDs   []string          `json:"collectionIds"`
+	ComparePrice    *Money            `json:"comparePrice"`
+	CostOfGood      Money             `json:"costOfGood"`
+	DepartmentCode  string            `json:"departmentCode"`
+	DepartmentID    string            `json:"departmentId"`
+	IsPriceOverride bool              `json:"isPriceOverride"`
+	//ItemData            string   `json:"itemData"`
+	//Modifiers           string   `json:"modifiers"`
+	//Notes               []string `json:"notes"`
+	OriginalPrice      Money  `json:"originalPrice"`
+	ProductSlug        string `json:"productSlug"`
+	QuantitySentToPrep int    `json:"quantitySentToPrep"`
+	TaxType            string `json:"taxType"`
+	Type               string `json:"type"`
+	//Vendor              string  `json:"vendor"`
+	HasShipping         bool          `json:"hasShipping"`
+	ID                  string        `json:"id"`
+	Image               string        `json:"image"`
+	InventoryLocationID string        `json:"inventoryLocationId"`
+	Price               Money         `json:"price"`
+	ProductID           string        `json:"productId"`
+	ProductTitle        string        `json:"productTitle"`
+	ProductVariantID    string        `json:"productVariantId"`
+	ProductVariantTitle string        `json:"productVariantTitle"`
+	PurchaseType        string        `json:"purchaseType"`
+	Quantity            int           `json:"quantity"`
+	QuantityFulfilled   int           `json:"quantityFulfilled"`
+	Sku                 string        `json:"sku"`
+	Tax                 Money         `json:"tax"`
+	VolumeInMl          int           `json:"volumeInML"`
+	Weight              float64       `json:"weight"`
+	Unknown             UnknownFields `json:"-"`
+}
+
+func (i *OrderItem) UnmarshalJSON(data []byte) error {
+	type alias OrderItem
+	return decodeWithUnknown(data, (*alias)(i), &i.Unknown)
+}
+
+func (i OrderItem) MarshalJSON() ([]byte, error) {
+	type alias OrderItem
+	return encodeWithUnknown(alias(i), i.Unknown)
+}
+
+// OrderBundleItem is a component of a bundle line on an order.
+type OrderBundleItem struct {
+	//AllocationID               string   `json:"allocationId"`
+	//BottleDeposit              int      `json:"bottleDeposit"`
+	//CollectionIds              []string `json:"collectionIds"`
+	//ComparePrice               int      `json:"comparePrice"`
+	//CostOfGood                 int      `json:"costOfGood"`
+	//DepartmentCode             string   `json:"departmentCode"`
+	//DepartmentID               string   `json:"departmentId"`
+	//IsOverrideOperatingRegions bool     `json:"isOverrideOperatingRegions"`
+	//IsPriceOverride     bool    `json:"isPriceOverride"`
+	//OriginalPrice       float64 `json:"originalPrice"`
+	//ProductSlug                string   `json:"productSlug"`
+	//Vendor              string  `json:"vendor"`
+	HasInventory        bool    `json:"hasInventory"`
+	HasShipping         bool    `json:"hasShipping"`
+	Image               string  `json:"image"`
+	InventoryLocationID string  `json:"inventoryLocationId"`
+	Price               Money   `json:"price"`
+	ProductID           string  `json:"productId"`
+	ProductTitle        string  `json:"productTitle"`
+	ProductVariantID    string  `json:"productVariantId"`
+	ProductVariantTitle string  `json:"productVariantTitle"`
+	Quantity            int     `json:"quantity"`
+	Sku                 string  `json:"sku"`
+	Tax                 Money   `json:"tax"`
+	TaxType             string  `json:"taxType"`
+	Type                string  `json:"type"`
+	VolumeInMl          int     `json:"volumeInML"`
+	Weight              float64 `json:"weight"`
+}
+
+// OrderLink points from an order to a related one, such as its refunds.
+type OrderLink struct {
+	OrderID      string `json:"orderId"`
+	OrderNumber  int    `json:"orderNumber"`
+	PurchaseType string `json:"purchaseType"`
+}
+
+type OrderTag struct {
+	AppliesToCondition string    `json:"appliesToCondition"`
+	CreatedAt          time.Time `json:"createdAt"`
+	ID                 string    `json:"id"`
+	ObjectType         string    `json:"objectType"`
+	Title              string    `json:"title"`
+	Type               string    `json:"type"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+type OrderSalesAssociate struct {
+	AccountID string `json:"accountId"`
+	Name      string `json:"name"`
+}
+
+type OrderSelectedShippingOptions struct {
+	RequestedShipDate string `json:"requestedShipDate,omitempty"`
+	ShippingServiceID string `json:"shippingServiceId,omitempty"`
+}
+
+type OrderShipping struct {
+	ID                string `json:"id"`
+	Carrier           string `json:"carrier"`
+	Code              string `json:"code"`
+	OriginalPrice     Money  `json:"originalPrice"`
+	Price             Money  `json:"price"`
+	Service           string `json:"service"`
+	ShippingServiceID string `json:"shippingServiceId"`
+	Tax               Money  `json:"tax"`
+	Title             string `json:"title"`
+	//ProcessorResponse string `json:"processorResponse"`
+	//Vendor            string `json:"vendor"`
+}
+
+// OrderTax is one tax line on an order. The per-category fields are the tax
+// rates applied to that category of product.
+type OrderTax struct {
+	ID                 string  `json:"id"`
+	Title              string  `json:"title"`
+	Price              Money   `json:"price"`
+	CountryCode        string  `json:"countryCode"`
+	StateCode          string  `json:"stateCode"`
+	IsFlatRate         bool    `json:"isFlatRate"`
+	IsIncludedInPrice  bool    `json:"isIncludedInPrice"`
+	IsNonTaxable       bool    `json:"isNonTaxable"`
+	SortOrder          int     `json:"sortOrder"`
+	Vendor             string  `json:"vendor"`
+	Wine               float64 `json:"wine"`
+	Food               float64 `json:"food"`
+	GeneralMerchandise float64 `json:"generalMerchandise"`
+	Cannabis           float64 `json:"cannabis"`
+	Freight            float64 `json:"freight"`
+}
+
+// OrderTender is one payment on an order. Only the part matching TenderType
+// is set.
+type OrderTender struct {
+	ID                 string                 `json:"id"`
+	AmountTendered     Money                  `json:"amountTendered"`
+	ChargeStatus       string                 `json:"chargeStatus"`
+	ChargeType         string                 `json:"chargeType"`
+	CreatedAt          time.Time              `json:"createdAt"`
+	CreditCard         *OrderTenderCreditCard `json:"creditCard"`
+	ErrorCode          string                 `json:"errorCode"`
+	GiftCard           *OrderTenderGiftCard   `json:"giftCard"`
+	Loyalty            *OrderTenderLoyalty    `json:"loyalty"`
+	OtherPaymentMethod string                 `json:"otherPaymentMethod"`
+	PaymentDate        *time.Time             `json:"paymentDate"`
+	PreviousTenderID   string                 `json:"previousTenderId"`
+	TenderType         string                 `json:"tenderType"` // See TenderType constants
+	Tip                Money                  `json:"tip"`
+	UpdatedAt          time.Time              `json:"updatedAt"`
+}
+
+type OrderTenderCreditCard struct {
+	AuthorizationID      string `json:"authorizationId"`
+	Bin                  string `json:"bin"`
+	CardBrand            string `json:"cardBrand"`
+	CardHolderName       string `json:"cardHolderName"`
+	CustomerCreditCardID string `json:"customerCreditCardId"`
+	ExpiryMo             int    `json:"expiryMo"`
+	ExpiryYr             int    `json:"expiryYr"`
+	Gateway              string `json:"gateway"`
+	MaskedCardNumber     string `json:"maskedCardNumber"`
+	ProcessorResponse    string `json:"processorResponse"`
+	TokenOnFile          string `json:"tokenOnFile"`
+	//OneTimeToken         string `json:"oneTimeToken"`
+}
+
+type OrderTenderGiftCard struct {
+	ID            string `json:"id"`
+	MaskedCode    string `json:"maskedCode"`
+	TransactionID string `json:"transactionId"`
+}
+
+type OrderTenderLoyalty struct {
+	Points        int    `json:"points"`
+	TransactionID string `json:"transactionId"`
 }
 
 type C7OrderFulfillment struct {
-	ID                  string    `json:"id"`
-	InventoryLocationID string    `json:"inventoryLocationId"`
-	Type                string    `json:"type"`
-	FulfillmentDate     time.Time `json:"fulfillmentDate"`
-	PackageCount        int       `json:"packageCount"`
-	Items               []struct {
-		ID                string `json:"id"`
-		QuantityFulfilled int    `json:"quantityFulfilled"`
-	} `json:"items"`
-	Shipped struct {
-		TrackingNumbers []string `json:"trackingNumbers"`
-		Carrier         string   `json:"carrier"`
-	} `json:"shipped"`
+	ID                  string                 `json:"id"`
+	InventoryLocationID string                 `json:"inventoryLocationId"`
+	Type                string                 `json:"type"`
+	FulfillmentDate     time.Time              `json:"fulfillmentDate"`
+	PackageCount        int                    `json:"packageCount"`
+	Items               []OrderFulfillmentItem `json:"items"`
+	Shipped             FulfillmentShipped     `json:"shipped"`
+}
+
+type OrderFulfillmentItem struct {
+	ID                string `json:"id"`
+	QuantityFulfilled int    `json:"quantityFulfilled"`
+}
+
+// OrderPost is the payload for creating an order. Build one with
+// NewOrderBuilder rather than by hand; the builder validates the combinations
+// Commerce7 requires.
+type OrderPost struct {
+	Channel              string              `json:"channel"` // See OrderChannel constants
+	CustomerID           string              `json:"customerId"`
+	OrderDeliveryMethod  string              `json:"orderDeliveryMethod"` // See OrderDeliveryMethod constants
+	OrderSubmittedDate   *time.Time          `json:"orderSubmittedDate,omitempty"`
+	BillTo               *Address            `json:"billTo,omitempty"`
+	ShipTo               *Address            `json:"shipTo,omitempty"`
+	InventoryLocationID  string              `json:"inventoryLocationId,omitempty"` // Pickup and Carry Out orders
+	Items                []OrderItemPost     `json:"items"`
+	Shipping             []OrderShippingPost `json:"shipping,omitempty"`
+	Tenders              []TenderPost        `json:"tenders,omitempty"`
+	IsNonTaxable         bool                `json:"isNonTaxable"`
+	SalesAttributeCode   string              `json:"salesAttributeCode,omitempty"`
+	ShippingInstructions string              `json:"shippingInstructions,omitempty"`
+}
+
+type OrderItemPost struct {
+	ProductVariantID string `json:"productVariantId"`
+	Sku              string `json:"sku"`
+	Quantity         int    `json:"quantity"`
+	Price            Money  `json:"price"`
+	TaxType          string `json:"taxType,omitempty"`
+	Tax              *Money `json:"tax,omitempty"` // Overrides the calculated tax for the line when set
+}
+
+type OrderShippingPost struct {
+	ShippingServiceID string `json:"shippingServiceId,omitempty"`
+	Title             string `json:"title"`
+	Carrier           string `json:"carrier,omitempty"`
+	Price             Money  `json:"price"`
+}
+
+// RefundPost is the payload for refunding an order. Items reference lines on
+// the original order. Amounts are given as positive numbers; the refund order
+// Commerce7 creates carries them negated.
+type RefundPost struct {
+	Items          []RefundItemPost `json:"items"`
+	ShippingAmount Money            `json:"shippingAmount"`
+	IsRestock      bool             `json:"isRestock"`
+	Tenders        []TenderPost     `json:"tenders,omitempty"` // Defaults to refunding the original tenders
+	Notes          string           `json:"notes,omitempty"`
+}
+
+type RefundItemPost struct {
+	OrderItemID string `json:"orderItemId"`
+	Quantity    int    `json:"quantity"`
 }
@@ -0,0 +1,217 @@
+package c7api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// A Ship order paid by card, gift card and loyalty, trimmed from a real
+// response but keeping its shape.
+const sampleShipOrder = `{
+	"id": "a1b2c3d4-0000-4000-8000-000000000001",
+	"orderNumber": 10452,
+	"channel": "Web",
+	"purchaseType": "Order",
+	"orderDeliveryMethod": "Ship",
+	"orderSubmittedDate": "2024-03-01T18:22:41.000Z",
+	"orderPaidDate": "2024-03-01T18:22:45.000Z",
+	"orderFulfilledDate": null,
+	"createdAt": "2024-03-01T18:20:00.000Z",
+	"updatedAt": "2024-03-02T09:00:00.000Z",
+	"paymentStatus": "Paid",
+	"fulfillmentStatus": "Partially Fulfilled",
+	"shippingStatus": "Not Shipped",
+	"complianceStatus": "Approved",
+	"fraudCheckStatus": "Passed",
+	"isNonTaxable": false,
+	"isNoDuty": false,
+	"customerId": "cust-1",
+	"customer": {
+		"id": "cust-1",
+		"firstName": "Ada",
+		"lastName": "Lovelace",
+		"birthDate": "1985-12-10",
+		"emails": [{"id": "e1", "email": "ada@example.com", "status": "Subscribed"}],
+		"phones": [{"id": "p1", "phone": "+17075550100"}],
+		"flags": [{"id": "f1", "content": "Prefers screw caps"}],
+		"clubs": [{"clubId": "club-1", "clubTitle": "Reserve", "clubMembershipId": "cm-1", "signupDate": "2022-01-05T00:00:00.000Z", "cancelDate": null}],
+		"orderInformation": {"currentClubTitle": "Reserve"},
+		"products": [{"product": {"productId": "prod-1", "sku": "CAB21-750", "title": "2021 Cabernet", "price": 4500, "quantity": 6, "image": "", "wineProperties": {"type": "Red", "vintage": 2021}}, "purchaseDate": "2023-11-01T00:00:00.000Z"}],
+		"metaData": {"favourite-varietal": "Cabernet"}
+	},
+	"billTo": {"firstName": "Ada", "lastName": "Lovelace", "address": "1 Vine St", "city": "Napa", "stateCode": "CA", "zipCode": "94558", "countryCode": "US", "phone": "+17075550100"},
+	"shipTo": {"id": "addr-2", "customerAddressId": "ca-2", "firstName": "Ada", "lastName": "Lovelace", "company": "Analytical Engines", "address": "2 Cellar Rd", "address2": "Suite 5", "city": "Sonoma", "stateCode": "CA", "zipCode": "95476", "countryCode": "US"},
+	"carryOut": null,
+	"pickupBy": null,
+	"club": {"id": "oc-1", "clubId": "club-1", "clubTitle": "Reserve", "clubPackageId": "pkg-1", "clubPackageTitle": "Spring 2024", "shipmentBuildStatus": "Completed"},
+	"connectionInformation": {"customerIpAddress": "203.0.113.9", "userAgent": "Mozilla/5.0"},
+	"salesAssociate": {"accountId": "acct-7", "name": "Grace Hopper"},
+	"selectedShippingOptions": {"requestedShipDate": "2024-03-04", "shippingServiceId": "ss-1"},
+	"items": [
+		{
+			"id": "item-1", "productId": "prod-1", "productVariantId": "var-1", "sku": "CAB21-750",
+			"productTitle": "2021 Cabernet", "productVariantTitle": "750ml", "productSlug": "2021-cabernet",
+			"type": "Wine", "taxType": "Wine", "purchaseType": "Order",
+			"price": 4500, "originalPrice": 5000, "comparePrice": 5500, "costOfGood": 1200, "isPriceOverride": true,
+			"quantity": 6, "quantityFulfilled": 2, "tax": 1890, "bottleDeposit": 0,
+			"hasShipping": true, "inventoryLocationId": "loc-1", "departmentId": "dept-1", "departmentCode": "WINE",
+			"collectionIds": ["col-1", "col-2"], "volumeInML": 750, "weight": 1.4, "bundleItems": []
+		},
+		{
+			"id": "item-2", "productId": "prod-2", "productVariantId": "var-2", "sku": "TRIO",
+			"productTitle": "Tasting Trio", "type": "Bundle", "taxType": "Wine", "price": 9900, "quantity": 1,
+			"comparePrice": null, "hasShipping": true,
+			"bundleItems": [
+				{"productId": "prod-3", "productVariantId": "var-3", "sku": "CHARD22-375", "productTitle": "2022 Chardonnay", "price": 3300, "quantity": 1, "tax": 254.1, "taxType": "Wine", "type": "Wine", "hasInventory": true, "hasShipping": true, "volumeInML": 375, "weight": 0.8}
+			]
+		}
+	],
+	"coupons": [{"id": "oc-9", "couponId": "coupon-9", "code": "SPRING10", "title": "Spring 10% off", "inUse": true, "productValue": -3600, "shippingValue": 0, "totalValue": -3600}],
+	"promotions": [{"id": "op-1", "promotionId": "promo-1", "title": "Free shipping over $150", "productValue": 0, "shippingValue": -1500, "totalValue": -1500}],
+	"shipping": [{"id": "sh-1", "title": "Ground", "code": "GND", "carrier": "UPS", "service": "Ground", "shippingServiceId": "ss-1", "price": 0, "originalPrice": 1500, "tax": 0}],
+	"taxes": [
+		{"id": "tx-1", "title": "CA State Tax", "countryCode": "US", "stateCode": "CA", "price": 2144, "wine": 7.25, "food": 0, "generalMerchandise": 7.25, "cannabis": 0, "freight": 0, "isFlatRate": false, "isIncludedInPrice": false, "isNonTaxable": false, "sortOrder": 1, "vendor": "Avalara"}
+	],
+	"tenders": [
+		{"id": "tn-1", "tenderType": "Credit Card", "amountTendered": 30000, "chargeType": "Sale", "chargeStatus": "Success", "tip": 0, "paymentDate": "2024-03-01T18:22:45.000Z", "createdAt": "2024-03-01T18:22:45.000Z",
+		 "creditCard": {"cardBrand": "Visa", "maskedCardNumber": "************4242", "expiryMo": 4, "expiryYr": 2027, "cardHolderName": "Ada Lovelace", "gateway": "Stripe", "bin": "424242", "customerCreditCardId": "ccc-1", "authorizationId": "auth-1", "tokenOnFile": "tok_1", "processorResponse": "Approved"}},
+		{"id": "tn-2", "tenderType": "Gift Card", "amountTendered": 5000, "chargeStatus": "Success", "giftCard": {"id": "gc-1", "maskedCode": "****WXYZ", "transactionId": "gct-1"}},
+		{"id": "tn-3", "tenderType": "Loyalty", "amountTendered": 1200, "chargeStatus": "Success", "loyalty": {"points": 1200, "transactionId": "lt-1"}}
+	],
+	"fraud": {"isFraudulent": false, "comment": ""},
+	"flags": [{"id": "of-1", "content": "Gift"}],
+	"tags": [{"id": "tag-1", "title": "VIP", "type": "Manual", "objectType": "Order", "appliesToCondition": null}],
+	"orderTags": [{"id": "tag-1", "title": "VIP", "type": "Manual", "objectType": "Order", "createdAt": "2023-01-01T00:00:00.000Z", "updatedAt": "2023-01-01T00:00:00.000Z"}],
+	"linkedOrders": [{"orderId": "a1b2c3d4-0000-4000-8000-000000000002", "orderNumber": 10460, "purchaseType": "Refund"}],
+	"fulfillments": [
+		{"id": "ful-1", "type": "Shipped", "fulfillmentDate": "2024-03-02T09:00:00.000Z", "packageCount": 1, "inventoryLocationId": "loc-1",
+		 "items": [{"id": "item-1", "quantityFulfilled": 2}], "shipped": {"carrier": "UPS", "trackingNumbers": ["1Z999AA10123456784"]}}
+	],
+	"subTotal": 36000, "shipTotal": 0, "taxTotal": 2144, "dutyTotal": 0, "bottleDepositTotal": 0,
+	"total": 36200, "tipTotal": 0, "totalAfterTip": 36200,
+	"giftMessage": "Happy birthday!", "shippingInstructions": "Leave at the door", "taxSaleType": "Shipped",
+	"loyaltyPointsEarned": 362, "orderSource": "Website"
+}`
+
+// A POS carry out order collected in person, with cash.
+const samplePickupOrder = `{
+	"id": "a1b2c3d4-0000-4000-8000-000000000003",
+	"orderNumber": 10453,
+	"channel": "POS",
+	"purchaseType": "Order",
+	"orderDeliveryMethod": "Carry Out",
+	"posProfileId": "pos-1",
+	"carryOut": {"inventoryLocationId": "loc-2", "firstName": "Alan", "lastName": "Turing", "address": "3 Barrel Way", "city": "Napa", "stateCode": "CA", "zipCode": "94558", "countryCode": "US"},
+	"pickupBy": {"inventoryLocationId": "loc-2", "firstName": "Alan", "lastName": "Turing", "address": "", "city": "", "stateCode": "", "zipCode": "", "countryCode": "US"},
+	"items": [{"id": "item-9", "sku": "ROSE23-750", "price": 2800, "quantity": 1, "tax": 203, "comparePrice": null}],
+	"tenders": [{"id": "tn-9", "tenderType": "Cash", "amountTendered": 3003, "chargeStatus": "Success", "creditCard": null, "giftCard": null, "loyalty": null}],
+	"club": null,
+	"fraud": null,
+	"salesAssociate": null,
+	"total": 3003
+}`
+
+func TestC7Order_DecodeShipOrder(t *testing.T) {
+	var order C7Order
+	if err := json.Unmarshal([]byte(sampleShipOrder), &order); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if order.OrderNumber != 10452 || order.OrderFulfilledDate != nil {
+		t.Errorf("order = %d, fulfilled %v", order.OrderNumber, order.OrderFulfilledDate)
+	}
+	if order.ShipTo.Company != "Analytical Engines" || order.ShipTo.CustomerAddressID != "ca-2" || order.BillTo.City != "Napa" {
+		t.Errorf("addresses = %+v / %+v", order.BillTo, order.ShipTo)
+	}
+	if order.Club == nil || order.Club.ClubPackageTitle != "Spring 2024" {
+		t.Errorf("club = %+v", order.Club)
+	}
+	if order.SalesAssociate == nil || order.SalesAssociate.Name != "Grace Hopper" {
+		t.Errorf("sales associate = %+v", order.SalesAssociate)
+	}
+	if order.SelectedShippingOptions.ShippingServiceID != "ss-1" {
+		t.Errorf("selected shipping = %+v", order.SelectedShippingOptions)
+	}
+
+	customer := order.Customer
+	if customer.Emails[0].Email != "ada@example.com" || customer.Phones[0].ID != "p1" || customer.Flags[0].Content != "Prefers screw caps" {
+		t.Errorf("customer contact = %+v", customer)
+	}
+	if !customer.Clubs[0].CancelDate.IsZero() || customer.Clubs[0].SignupDate.Year() != 2022 {
+		t.Errorf("customer clubs = %+v", customer.Clubs)
+	}
+	if p := customer.Products[0].Product; p.Sku != "CAB21-750" || p.WineProperties == nil || *p.WineProperties.Vintage != 2021 {
+		t.Errorf("customer products = %+v", customer.Products)
+	}
+
+	var cab OrderItem = order.Items[0]
+	if cab.Quantity-cab.QuantityFulfilled != 4 || cab.ComparePrice == nil || cab.ComparePrice.Cents != 5500 || len(cab.CollectionIDs) != 2 || !cab.IsPriceOverride {
+		t.Errorf("item = %+v", cab)
+	}
+	if trio := order.Items[1]; trio.ComparePrice != nil || len(trio.BundleItems) != 1 || trio.BundleItems[0].Sku != "CHARD22-375" {
+		t.Errorf("bundle = %+v", trio)
+	}
+
+	if order.Coupons[0].Code != "SPRING10" || order.Coupons[0].TotalValue.Cents != -3600 {
+		t.Errorf("coupons = %+v", order.Coupons)
+	}
+	if order.Promotions[0].ShippingValue.Cents != -1500 {
+		t.Errorf("promotions = %+v", order.Promotions)
+	}
+	if order.Shipping[0].OriginalPrice.Cents != 1500 {
+		t.Errorf("shipping = %+v", order.Shipping)
+	}
+
+	var tax OrderTax = order.Taxes[0]
+	if tax.Price.Cents != 2144 || tax.Wine != 7.25 || tax.Vendor != "Avalara" {
+		t.Errorf("taxes = %+v", order.Taxes)
+	}
+
+	var card, gift, points OrderTender = order.Tenders[0], order.Tenders[1], order.Tenders[2]
+	if card.CreditCard == nil || card.CreditCard.MaskedCardNumber != "************4242" || card.CreditCard.ExpiryYr != 2027 || card.GiftCard != nil {
+		t.Errorf("card tender = %+v", card)
+	}
+	if card.PaymentDate == nil || !card.PaymentDate.Equal(time.Date(2024, 3, 1, 18, 22, 45, 0, time.UTC)) {
+		t.Errorf("card payment date = %v", card.PaymentDate)
+	}
+	if gift.GiftCard == nil || gift.GiftCard.MaskedCode != "****WXYZ" || gift.CreditCard != nil {
+		t.Errorf("gift card tender = %+v", gift)
+	}
+	if points.Loyalty == nil || points.Loyalty.Points != 1200 {
+		t.Errorf("loyalty tender = %+v", points)
+	}
+
+	if order.Fraud == nil || order.Fraud.IsFraudulent {
+		t.Errorf("fraud = %+v", order.Fraud)
+	}
+	if len(order.LinkedOrders) != 1 || order.LinkedOrders[0].PurchaseType != OrderPurchaseTypeRefund {
+		t.Errorf("linked orders = %+v", order.LinkedOrders)
+	}
+	if order.Tags[0].Title != "VIP" || order.OrderTags[0].CreatedAt.Year() != 2023 {
+		t.Errorf("tags = %+v / %+v", order.Tags, order.OrderTags)
+	}
+	if f := order.Fulfillments[0]; f.Shipped.Carrier != "UPS" || f.Items[0].QuantityFulfilled != 2 {
+		t.Errorf("fulfillments = %+v", order.Fulfillments)
+	}
+}
+
+func TestC7Order_DecodePickupOrder(t *testing.T) {
+	var order C7Order
+	if err := json.Unmarshal([]byte(samplePickupOrder), &order); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if order.CarryOut == nil || order.CarryOut.InventoryLocationID != "loc-2" || order.CarryOut.FirstName != "Alan" {
+		t.Errorf("carry out = %+v", order.CarryOut)
+	}
+	if order.PickupBy == nil || order.PickupBy.LastName != "Turing" {
+		t.Errorf("pickup by = %+v", order.PickupBy)
+	}
+	if order.Club != nil || order.Fraud != nil || order.SalesAssociate != nil {
+		t.Errorf("null parts decoded as set: club %v, fraud %v, associate %v", order.Club, order.Fraud, order.SalesAssociate)
+	}
+	if tender := order.Tenders[0]; tender.TenderType != TenderTypeCash || tender.CreditCard != nil || tender.GiftCard != nil || tender.Loyalty != nil {
+		t.Errorf("tender = %+v", tender)
+	}
+}
@@ -0,0 +1,209 @@
+package c7api
+
+import "time"
+
+// C7Products is the paged wrapper returned by GET /product.
+type C7Products struct {
+	Products []C7Product `json:"products"`
+	Total    int         `json:"total"`
+}
+
+func (p C7Products) GetItems() []C7Product { return p.Products }
+func (p C7Products) GetTotal() int         { return p.Total }
+
+// C7Product is a product definition as returned by the product endpoint.
+//
+// Not to be confused with Product, which is a customer's purchase history
+// entry on CustomerFull.
+type C7Product struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	SubTitle    string             `json:"subTitle"`
+	Slug        string             `json:"slug"`
+	Type        string             `json:"type"`        // See ProductType constants
+	AdminStatus string             `json:"adminStatus"` // See AdminStatus constants
+	WebStatus   string             `json:"webStatus"`   // See WebStatus constants
+	Content     string             `json:"content"`
+	Teaser      string             `json:"teaser"`
+	Image       string             `json:"image"`
+	Department  *ProductDepartment `json:"department"`
+	Vendor      *ProductVendor     `json:"vendor"`
+	Collections []ProductRef       `json:"collections"`
+	SEO         *ProductSEO        `json:"seo"`
+	Wine        *WineProperties    `json:"wine"`
+	Variants    []C7ProductVariant `json:"variants"`
+	MetaData    map[string]any     `json:"metaData"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	Unknown     UnknownFields      `json:"-"`
+}
+
+func (p *C7Product) UnmarshalJSON(data []byte) error {
+	type alias C7Product
+	return decodeWithUnknown(data, (*alias)(p), &p.Unknown)
+}
+
+func (p C7Product) MarshalJSON() ([]byte, error) {
+	type alias C7Product
+	return encodeWithUnknown(alias(p), p.Unknown)
+}
+
+// VariantBySku returns the variant with the given SKU, or nil.
+func (p *C7Product) VariantBySku(sku string) *C7ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].Sku == sku {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// C7ProductVariant is a purchasable variant of a product. Prices are in cents.
+type C7ProductVariant struct {
+	ID           string        `json:"id"`
+	Title        string        `json:"title"`
+	Sku          string        `json:"sku"`
+	Price        int           `json:"price"`
+	ComparePrice *int          `json:"comparePrice"`
+	CostOfGood   *int          `json:"costOfGood"`
+	TaxType      string        `json:"taxType"` // See TaxType constants
+	HasInventory bool          `json:"hasInventory"`
+	HasShipping  bool          `json:"hasShipping"`
+	Weight       *float64      `json:"weight"`
+	VolumeInMl   *int          `json:"volumeInML"`
+	SortOrder    int           `json:"sortOrder"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	UpdatedAt    time.Time     `json:"updatedAt"`
+	Unknown      UnknownFields `json:"-"`
+}
+
+func (v *C7ProductVariant) UnmarshalJSON(data []byte) error {
+	type alias C7ProductVariant
+	return decodeWithUnknown(data, (*alias)(v), &v.Unknown)
+}
+
+func (v C7ProductVariant) MarshalJSON() ([]byte, error) {
+	type alias C7ProductVariant
+	return encodeWithUnknown(alias(v), v.Unknown)
+}
+
+type ProductDepartment struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Code  string `json:"code"`
+}
+
+type ProductVendor struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type ProductRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type ProductSEO struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// ProductPost is the payload for creating or updating a product.
+//
+// Commerce7 rejects properties it doesn't accept on write, so this only
+// carries the writable fields of C7Product, plus what ToPost carries over
+// from the product's Unknown.
+type ProductPost struct {
+	Title         string               `json:"title"`
+	SubTitle      string               `json:"subTitle,omitempty"`
+	Slug          string               `json:"slug,omitempty"`
+	Type          string               `json:"type"`
+	AdminStatus   string               `json:"adminStatus"`
+	WebStatus     string               `json:"webStatus"`
+	Content       string               `json:"content,omitempty"`
+	Teaser        string               `json:"teaser,omitempty"`
+	DepartmentID  string               `json:"departmentId,omitempty"`
+	VendorID      string               `json:"vendorId,omitempty"`
+	CollectionIDs []string             `json:"collectionIds,omitempty"`
+	SEO           *ProductSEO          `json:"seo,omitempty"`
+	Wine          *WineProperties      `json:"wine,omitempty"`
+	Variants      []ProductVariantPost `json:"variants"`
+	MetaData      map[string]any       `json:"metaData,omitempty"`
+	Unknown       UnknownFields        `json:"-"`
+}
+
+func (p ProductPost) MarshalJSON() ([]byte, error) {
+	type alias ProductPost
+	return encodeWithUnknown(alias(p), p.Unknown)
+}
+
+// ProductVariantPost is a variant within a ProductPost. ID is set when
+// updating an existing variant and left blank to create a new one.
+type ProductVariantPost struct {
+	ID           string        `json:"id,omitempty"`
+	Title        string        `json:"title"`
+	Sku          string        `json:"sku"`
+	Price        int           `json:"price"`
+	ComparePrice *int          `json:"comparePrice,omitempty"`
+	CostOfGood   *int          `json:"costOfGood,omitempty"`
+	TaxType      string        `json:"taxType"`
+	HasInventory bool          `json:"hasInventory"`
+	HasShipping  bool          `json:"hasShipping"`
+	Weight       *float64      `json:"weight,omitempty"`
+	VolumeInMl   *int          `json:"volumeInML,omitempty"`
+	SortOrder    int           `json:"sortOrder"`
+	Unknown      UnknownFields `json:"-"`
+}
+
+func (v ProductVariantPost) MarshalJSON() ([]byte, error) {
+	type alias ProductVariantPost
+	return encodeWithUnknown(alias(v), v.Unknown)
+}
+
+// ToPost converts a product read from Commerce7 into the payload for writing
+// it back, keeping variant IDs so existing variants are updated in place and
+// carrying over what the product and its variants have in Unknown.
+func (p *C7Product) ToPost() ProductPost {
+	post := ProductPost{
+		Title:       p.Title,
+		SubTitle:    p.SubTitle,
+		Slug:        p.Slug,
+		Type:        p.Type,
+		AdminStatus: p.AdminStatus,
+		WebStatus:   p.WebStatus,
+		Content:     p.Content,
+		Teaser:      p.Teaser,
+		SEO:         p.SEO,
+		Wine:        p.Wine,
+		MetaData:    p.MetaData,
+		Variants:    make([]ProductVariantPost, 0, len(p.Variants)),
+		Unknown:     p.Unknown,
+	}
+	if p.Department != nil {
+		post.DepartmentID = p.Department.ID
+	}
+	if p.Vendor != nil {
+		post.VendorID = p.Vendor.ID
+	}
+	for _, collection := range p.Collections {
+		post.CollectionIDs = append(post.CollectionIDs, collection.ID)
+	}
+	for _, v := range p.Variants {
+		post.Variants = append(post.Variants, ProductVariantPost{
+			ID:           v.ID,
+			Title:        v.Title,
+			Sku:          v.Sku,
+			Price:        v.Price,
+			ComparePrice: v.ComparePrice,
+			CostOfGood:   v.CostOfGood,
+			TaxType:      v.TaxType,
+			HasInventory: v.HasInventory,
+			HasShipping:  v.HasShipping,
+			Weight:       v.Weight,
+			VolumeInMl:   v.VolumeInMl,
+			SortOrder:    v.SortOrder,
+			Unknown:      v.Unknown,
+		})
+	}
+	return post
+}
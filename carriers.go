@@ -0,0 +1,185 @@
+package c7api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Carrier is a shipping carrier Commerce7 accepts on fulfillments.
+type Carrier struct {
+	Name            string         // Canonical name, as sent to Commerce7
+	Aliases         []string       // Other spellings that should resolve to Name
+	TrackingPattern *regexp.Regexp // Matched against normalized tracking numbers; nil accepts any
+	TrackingURL     string         // Tracking page with a %s for the tracking number; blank if none
+}
+
+// ValidTrackingNumber reports whether tracking looks like one of this
+// carrier's tracking numbers, after NormalizeTrackingNumber.
+func (c *Carrier) ValidTrackingNumber(tracking string) bool {
+	tracking = NormalizeTrackingNumber(tracking)
+	if tracking == "" {
+		return false
+	}
+	return c.TrackingPattern == nil || c.TrackingPattern.MatchString(tracking)
+}
+
+// TrackingLink returns the carrier's tracking page for tracking, or "" if
+// the carrier has no tracking URL.
+func (c *Carrier) TrackingLink(tracking string) string {
+	if c.TrackingURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(c.TrackingURL, url.QueryEscape(NormalizeTrackingNumber(tracking)))
+}
+
+// The carriers Commerce7 supports on fulfillments. Add others with
+// RegisterCarrier.
+var defaultCarriers = []Carrier{
+	{
+		Name:            "UPS",
+		Aliases:         []string{"United Parcel Service"},
+		TrackingPattern: regexp.MustCompile(`^(1Z[0-9A-Z]{16}|T\d{10}|\d{9}|\d{26})$`),
+		TrackingURL:     "https://www.ups.com/track?tracknum=%s",
+	},
+	{
+		Name:            "FedEx",
+		Aliases:         []string{"Fed Ex", "Federal Express"},
+		TrackingPattern: regexp.MustCompile(`^(\d{12}|\d{15}|\d{20}|\d{22})$`),
+		TrackingURL:     "https://www.fedex.com/fedextrack/?trknbr=%s",
+	},
+	{
+		Name:            "GSO",
+		Aliases:         []string{"Golden State Overnight", "GLS US"},
+		TrackingPattern: regexp.MustCompile(`^[0-9A-Z]{8,20}$`),
+		TrackingURL:     "https://www.gls-us.com/track-and-trace?TrackingNumbers=%s",
+	},
+	{
+		Name:            "ATS Healthcare",
+		Aliases:         []string{"ATS"},
+		TrackingPattern: regexp.MustCompile(`^[0-9A-Z-]{6,30}$`),
+	},
+	{
+		Name:            "Australia Post",
+		Aliases:         []string{"AusPost", "Aus Post"},
+		TrackingPattern: regexp.MustCompile(`^([0-9A-Z]{10,23}|[A-Z]{2}\d{9}AU)$`),
+		TrackingURL:     "https://auspost.com.au/mypost/track/details/%s",
+	},
+}
+
+var (
+	carriersMu sync.RWMutex
+	carriers   = map[string]*Carrier{} // Keyed by carrierKey of name and aliases
+)
+
+func init() {
+	for _, c := range defaultCarriers {
+		if err := RegisterCarrier(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCarrier adds carrier to the registry, or replaces the carrier with
+// the same canonical name. It fails if a name or alias already belongs to a
+// different carrier.
+func RegisterCarrier(carrier Carrier) error {
+	if strings.TrimSpace(carrier.Name) == "" {
+		return errors.New("carrier requires a name")
+	}
+	if carrier.TrackingURL != "" && strings.Count(carrier.TrackingURL, "%s") != 1 {
+		return fmt.Errorf("carrier %s: tracking URL must contain exactly one %%s", carrier.Name)
+	}
+
+	carriersMu.Lock()
+	defer carriersMu.Unlock()
+
+	keys := []string{carrierKey(carrier.Name)}
+	for _, alias := range carrier.Aliases {
+		keys = append(keys, carrierKey(alias))
+	}
+	for _, key := range keys {
+		if existing, ok := carriers[key]; ok && existing.Name != carrier.Name {
+			return fmt.Errorf("carrier %s: %q already refers to %s", carrier.Name, key, existing.Name)
+		}
+	}
+
+	// Drop the old entry's aliases so a replacement can remove some.
+	for key, existing := range carriers {
+		if existing.Name == carrier.Name {
+			delete(carriers, key)
+		}
+	}
+
+	c := carrier
+	c.Aliases = append([]string(nil), carrier.Aliases...)
+	for _, key := range keys {
+		carriers[key] = &c
+	}
+	return nil
+}
+
+// LookupCarrier finds a carrier by name or alias, ignoring case, spaces and
+// punctuation, so "fed-ex" and "FEDEX" both find FedEx.
+func LookupCarrier(name string) (*Carrier, bool) {
+	key := carrierKey(name)
+	if key == "" {
+		return nil, false
+	}
+
+	carriersMu.RLock()
+	defer carriersMu.RUnlock()
+	c, ok := carriers[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *c
+	return &copied, true
+}
+
+// NormalizeCarrier returns the canonical name for carrier.
+func NormalizeCarrier(carrier string) (string, error) {
+	c, ok := LookupCarrier(carrier)
+	if !ok {
+		return "", fmt.Errorf("unsupported carrier: %q", carrier)
+	}
+	return c.Name, nil
+}
+
+// ValidateTracking normalizes carrier and each tracking number, failing on an
+// unknown carrier or a tracking number that doesn't fit the carrier's format.
+func ValidateTracking(carrier string, trackingNumbers []string) (string, []string, error) {
+	c, ok := LookupCarrier(carrier)
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported carrier: %q", carrier)
+	}
+
+	normalized := make([]string, 0, len(trackingNumbers))
+	for _, tracking := range trackingNumbers {
+		if !c.ValidTrackingNumber(tracking) {
+			return "", nil, fmt.Errorf("invalid %s tracking number: %q", c.Name, tracking)
+		}
+		normalized = append(normalized, NormalizeTrackingNumber(tracking))
+	}
+	return c.Name, normalized, nil
+}
+
+// NormalizeTrackingNumber upper-cases tracking and strips the spaces
+// carriers print for readability.
+func NormalizeTrackingNumber(tracking string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "\t", "").Replace(strings.TrimSpace(tracking)))
+}
+
+// carrierKey reduces a carrier name to upper-case letters and digits.
+func carrierKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package c7api
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestLookupCarrier_Aliases(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"FedEx", "FedEx"},
+		{"Fed Ex", "FedEx"},
+		{"FEDEX", "FedEx"},
+		{"fed-ex", "FedEx"},
+		{"Federal Express", "FedEx"},
+		{"ups", "UPS"},
+		{"AusPost", "Australia Post"},
+		{"ats healthcare", "ATS Healthcare"},
+	}
+	for _, tc := range tests {
+		got, err := NormalizeCarrier(tc.name)
+		if err != nil || got != tc.want {
+			t.Errorf("NormalizeCarrier(%q) = %q, %v, want %q", tc.name, got, err, tc.want)
+		}
+	}
+
+	if _, err := NormalizeCarrier("USPS"); err == nil {
+		t.Error("expected an error for an unsupported carrier")
+	}
+}
+
+func TestValidateTracking(t *testing.T) {
+	tests := []struct {
+		carrier  string
+		tracking string
+		wantErr  bool
+	}{
+		{"UPS", "1Z999AA10123456784", false},
+		{"UPS", "1z999aa1 0123 456784", false},
+		{"UPS", "1Z999", true},
+		{"FedEx", "794698123456", false},
+		{"FedEx", "79469812345X", true},
+		{"Australia Post", "CD123456789AU", false},
+		{"UPS", "", true},
+		{"DHL", "1234567890", true},
+	}
+	for _, tc := range tests {
+		_, _, err := ValidateTracking(tc.carrier, []string{tc.tracking})
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ValidateTracking(%q, %q) error = %v, wantErr %v", tc.carrier, tc.tracking, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCarrier_TrackingLink(t *testing.T) {
+	c, _ := LookupCarrier("ups")
+	if got, want := c.TrackingLink("1z999aa10123456784"), "https://www.ups.com/track?tracknum=1Z999AA10123456784"; got != want {
+		t.Errorf("TrackingLink = %q, want %q", got, want)
+	}
+
+	ats, _ := LookupCarrier("ATS Healthcare")
+	if got := ats.TrackingLink("ABC123"); got != "" {
+		t.Errorf("TrackingLink = %q, want blank for a carrier without a tracking URL", got)
+	}
+}
+
+func TestRegisterCarrier(t *testing.T) {
+	t.Cleanup(func() {
+		carriersMu.Lock()
+		for key, c := range carriers {
+			if c.Name == "Test Courier" {
+				delete(carriers, key)
+			}
+		}
+		carriersMu.Unlock()
+	})
+
+	err := RegisterCarrier(Carrier{
+		Name:            "Test Courier",
+		Aliases:         []string{"TC"},
+		TrackingPattern: regexp.MustCompile(`^TC\d{6}$`),
+		TrackingURL:     "https://example.com/track/%s",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !IsCarrierSupported("tc") {
+		t.Error("registered alias not supported")
+	}
+	if _, _, err := ValidateTracking("Test Courier", []string{"TC123456"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := RegisterCarrier(Carrier{Name: "Other", Aliases: []string{"Fed Ex"}}); err == nil {
+		t.Error("expected an error registering an alias owned by another carrier")
+	}
+	if err := RegisterCarrier(Carrier{Name: "Other", TrackingURL: "https://example.com"}); err == nil {
+		t.Error("expected an error for a tracking URL without a placeholder")
+	}
+}
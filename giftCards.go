@@ -1,18 +1,236 @@
 package c7api
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // For posting a new gift card
 type GiftCardPost struct {
 	Title         string `json:"title"`
 	Type          string `json:"type"`
 	Code          string `json:"code,omitempty"` // optional
 	Status        string `json:"status"`
-	InitialAmount int    `json:"initialAmount"`
+	InitialAmount Money  `json:"initialAmount"`
 	Notes         string `json:"notes,omitempty"`
 	ExpiryDate    string `json:"expiryDate,omitempty"` // optional
 }
 
 // Transaction struct for incrementing or decrementing a gift card
 type GiftCardTransactionPost struct {
-	Amount     int    `json:"amount"`     // Amount in cents
+	Amount     Money  `json:"amount"`
 	GiftCardId string `json:"giftCardId"` // UUID of the gift card
 }
+
+// For changing the status of an existing gift card
+type GiftCardStatusPut struct {
+	Status string `json:"status"`
+}
+
+type GiftCards struct {
+	GiftCards []GiftCard `json:"giftCards"`
+	Total     int        `json:"total"`
+}
+
+func (g GiftCards) GetItems() []GiftCard { return g.GiftCards }
+func (g GiftCards) GetTotal() int        { return g.Total }
+
+// GiftCard as returned by the gift-card endpoint. Amounts are in cents.
+type GiftCard struct {
+	ID             string        `json:"id"`
+	Code           string        `json:"code"`
+	Title          string        `json:"title"`
+	Type           string        `json:"type"`   // See GiftCardType constants
+	Status         string        `json:"status"` // See GiftCardStatus constants
+	InitialAmount  Money         `json:"initialAmount"`
+	CurrentBalance Money         `json:"currentBalance"`
+	CustomerID     string        `json:"customerId"`
+	Notes          string        `json:"notes"`
+	ExpiryDate     *time.Time    `json:"expiryDate"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+	Unknown        UnknownFields `json:"-"`
+}
+
+func (g *GiftCard) UnmarshalJSON(data []byte) error {
+	type alias GiftCard
+	return decodeWithUnknown(data, (*alias)(g), &g.Unknown)
+}
+
+func (g GiftCard) MarshalJSON() ([]byte, error) {
+	type alias GiftCard
+	return encodeWithUnknown(alias(g), g.Unknown)
+}
+
+type GiftCardTransactions struct {
+	GiftCardTransactions []GiftCardTransaction `json:"giftCardTransactions"`
+	Total                int                   `json:"total"`
+}
+
+func (g GiftCardTransactions) GetItems() []GiftCardTransaction { return g.GiftCardTransactions }
+func (g GiftCardTransactions) GetTotal() int                   { return g.Total }
+
+// GiftCardTransaction is a single movement on a gift card. Amount is in cents,
+// positive for increments and negative for redemptions and decrements.
+type GiftCardTransaction struct {
+	ID          string    `json:"id"`
+	GiftCardID  string    `json:"giftCardId"`
+	Amount      Money     `json:"amount"`
+	OrderID     string    `json:"orderId"`
+	OrderNumber int       `json:"orderNumber"`
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// CreateGiftCard issues a new gift card. Leave Code blank to have Commerce7
+// generate one.
+func CreateGiftCard(giftCard *GiftCardPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCard, error) {
+	if giftCard == nil {
+		return nil, errors.New("gift card cannot be nil")
+	}
+	if giftCard.Title == "" {
+		return nil, errors.New("gift card title is required")
+	}
+	if !IsValidGiftCardType(giftCard.Type) {
+		return nil, fmt.Errorf("invalid gift card type: %s", giftCard.Type)
+	}
+	if !IsValidGiftCardStatus(giftCard.Status) {
+		return nil, fmt.Errorf("invalid gift card status: %s", giftCard.Status)
+	}
+	if giftCard.InitialAmount.Cents <= 0 {
+		return nil, errors.New("gift card initial amount must be positive")
+	}
+
+	resp, err := Post(giftCard, Endpoints.GiftCard, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gift card: %w", err)
+	}
+
+	var created GiftCard
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gift card after create: %w", err)
+	}
+	return &created, nil
+}
+
+// GetGiftCards returns every gift card matching queries, walking all pages.
+func GetGiftCards(queries map[string]string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]GiftCard, error) {
+	giftCards, err := GetAll[GiftCard, GiftCards](Endpoints.GiftCard, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift cards: %w", err)
+	}
+	return giftCards, nil
+}
+
+func GetGiftCardById(giftCardId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCard, error) {
+	if giftCardId == "" {
+		return nil, errors.New("no giftCardId provided")
+	}
+
+	giftCard, err := Get[GiftCard](Endpoints.GiftCard+"/"+giftCardId, nil, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift card: %w", err)
+	}
+	return giftCard, nil
+}
+
+// GetGiftCardByCode searches for code and returns the card whose code matches
+// exactly, ignoring case.
+func GetGiftCardByCode(code string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCard, error) {
+	if code == "" {
+		return nil, errors.New("no gift card code provided")
+	}
+
+	giftCards, err := GetGiftCards(map[string]string{"q": code}, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, giftCard := range *giftCards {
+		if strings.EqualFold(giftCard.Code, code) {
+			return &giftCard, nil
+		}
+	}
+	return nil, fmt.Errorf("no gift card found with code: %s", code)
+}
+
+// GetGiftCardBalance returns the current balance of a gift card.
+func GetGiftCardBalance(giftCardId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (Money, error) {
+	giftCard, err := GetGiftCardById(giftCardId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return Money{}, err
+	}
+	return giftCard.CurrentBalance, nil
+}
+
+// GetGiftCardTransactions returns the full transaction history of a gift card.
+func GetGiftCardTransactions(giftCardId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]GiftCardTransaction, error) {
+	if giftCardId == "" {
+		return nil, errors.New("no giftCardId provided")
+	}
+
+	queries := map[string]string{"giftCardId": giftCardId}
+	transactions, err := GetAll[GiftCardTransaction, GiftCardTransactions](Endpoints.GiftCardTransaction, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift card transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// IncrementGiftCard adds amount to a gift card.
+func IncrementGiftCard(giftCardId string, amount Money, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCardTransaction, error) {
+	if amount.Cents <= 0 {
+		return nil, errors.New("increment amount must be positive")
+	}
+	return postGiftCardTransaction(giftCardId, amount, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// DecrementGiftCard removes amount from a gift card. amount is given as a
+// positive number.
+func DecrementGiftCard(giftCardId string, amount Money, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCardTransaction, error) {
+	if amount.Cents <= 0 {
+		return nil, errors.New("decrement amount must be positive")
+	}
+	return postGiftCardTransaction(giftCardId, amount.Neg(), tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// CancelGiftCard sets a gift card's status to Cancelled. Its balance can no
+// longer be redeemed.
+func CancelGiftCard(giftCardId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCard, error) {
+	if giftCardId == "" {
+		return nil, errors.New("no giftCardId provided")
+	}
+
+	payload := GiftCardStatusPut{Status: GiftCardStatusCancelled}
+	resp, err := Put(&payload, Endpoints.GiftCard+"/"+giftCardId, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel gift card: %w", err)
+	}
+
+	var updated GiftCard
+	if err := json.Unmarshal(*resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gift card after cancel: %w", err)
+	}
+	return &updated, nil
+}
+
+func postGiftCardTransaction(giftCardId string, amount Money, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCardTransaction, error) {
+	if giftCardId == "" {
+		return nil, errors.New("no giftCardId provided")
+	}
+
+	payload := GiftCardTransactionPost{Amount: amount, GiftCardId: giftCardId}
+	resp, err := Post(&payload, Endpoints.GiftCardTransaction, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post gift card transaction: %w", err)
+	}
+
+	var transaction GiftCardTransaction
+	if err := json.Unmarshal(*resp, &transaction); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal gift card transaction: %w", err)
+	}
+	return &transaction, nil
+}
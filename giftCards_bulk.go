@@ -0,0 +1,361 @@
+package c7api
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// giftCardCodeAlphabet leaves out 0/O and 1/I/L, which are easy to misread off
+// a printed card.
+const giftCardCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// DefaultGiftCardCodeLength is the code length used when none is given.
+const DefaultGiftCardCodeLength = 12
+
+// Row statuses in a bulk issue output file.
+const (
+	GiftCardIssuePending = "Pending" // Code reserved, create not yet confirmed
+	GiftCardIssueCreated = "Created"
+	GiftCardIssueFailed  = "Failed"
+)
+
+// GiftCardIssueRow is one card to issue.
+type GiftCardIssueRow struct {
+	Recipient string
+	Amount    Money
+}
+
+// GiftCardIssueOptions applies to every card in a bulk issue.
+type GiftCardIssueOptions struct {
+	Title      string // Defaults to "Gift Card"
+	Type       string // Defaults to GiftCardTypePhysical
+	CodeLength int    // Defaults to DefaultGiftCardCodeLength
+	Notes      string // Added to every card, after the recipient
+}
+
+// GiftCardIssueSummary counts the outcome of a bulk issue, including rows
+// completed by an earlier, interrupted run.
+type GiftCardIssueSummary struct {
+	Created int
+	Resumed int // Rows already created by a previous run and skipped
+	Failed  int
+}
+
+// GenerateGiftCardCode returns a random code of length characters from an
+// alphabet without easily confused characters.
+func GenerateGiftCardCode(length int) (string, error) {
+	if length <= 0 {
+		return "", errors.New("gift card code length must be positive")
+	}
+
+	max := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("while generating gift card code: %w", err)
+		}
+		code[i] = giftCardCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// ReadGiftCardIssueCSV reads recipient/amount rows from a CSV with a header
+// row. Columns are found by name ("recipient" and "amount", any case), and
+// amounts are in dollars, e.g. "50" or "49.99".
+func ReadGiftCardIssueCSV(r io.Reader) ([]GiftCardIssueRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("while reading gift card csv header: %w", err)
+	}
+
+	recipientCol, amountCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "recipient":
+			recipientCol = i
+		case "amount":
+			amountCol = i
+		}
+	}
+	if recipientCol < 0 || amountCol < 0 {
+		return nil, errors.New("gift card csv must have recipient and amount columns")
+	}
+
+	rows := []GiftCardIssueRow{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while reading gift card csv line %d: %w", line, err)
+		}
+
+		amount, err := parseDollarAmount(record[amountCol])
+		if err != nil {
+			return nil, fmt.Errorf("gift card csv line %d: %w", line, err)
+		}
+		if amount.Cents <= 0 {
+			return nil, fmt.Errorf("gift card csv line %d: amount must be positive", line)
+		}
+
+		rows = append(rows, GiftCardIssueRow{Recipient: strings.TrimSpace(record[recipientCol]), Amount: amount})
+	}
+	return rows, nil
+}
+
+// IssueGiftCards creates a card for each row, writing progress to out as CSV.
+//
+// out is an append-only log: a Pending line is written with the generated
+// code before each create, and a Created or Failed line after it. To resume
+// an interrupted run, pass the log so far as previous and keep appending to
+// the same file. The input must be unchanged: if a logged row's recipient or
+// amount no longer matches, nothing is issued and an error is returned.
+// Rows whose last line is Created are skipped; rows left
+// Pending are checked against Commerce7 by code, so a card that was created
+// just before the interruption is not issued twice.
+//
+// Codes are checked against every existing card before use. All requests go
+// through rl.
+func IssueGiftCards(rows []GiftCardIssueRow, opts GiftCardIssueOptions, previous io.Reader, out io.Writer, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCardIssueSummary, error) {
+	if opts.Title == "" {
+		opts.Title = "Gift Card"
+	}
+	if opts.Type == "" {
+		opts.Type = GiftCardTypePhysical
+	}
+	if opts.CodeLength == 0 {
+		opts.CodeLength = DefaultGiftCardCodeLength
+	}
+	if !IsValidGiftCardType(opts.Type) {
+		return nil, fmt.Errorf("invalid gift card type: %s", opts.Type)
+	}
+
+	prior := map[int]giftCardIssueLogLine{}
+	if previous != nil {
+		var err error
+		if prior, err = readGiftCardIssueLog(previous); err != nil {
+			return nil, err
+		}
+	}
+
+	// The log only knows rows by number, so an input edited or reordered
+	// since the last run would resume the wrong recipients. Refuse before
+	// issuing anything.
+	for rowNumber, last := range prior {
+		if rowNumber < 1 || rowNumber > len(rows) {
+			return nil, fmt.Errorf("gift card issue log has row %d but the input has %d rows", rowNumber, len(rows))
+		}
+		row := rows[rowNumber-1]
+		if last.Recipient != row.Recipient || last.Amount.Cents != row.Amount.Cents {
+			return nil, fmt.Errorf("gift card issue log row %d is for %q (%s), but the input row is %q (%s); the input changed since the last run",
+				rowNumber, last.Recipient, last.Amount, row.Recipient, row.Amount)
+		}
+	}
+
+	existing, err := GetGiftCards(nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, err
+	}
+	usedCodes := map[string]bool{}
+	for _, giftCard := range *existing {
+		usedCodes[strings.ToUpper(giftCard.Code)] = true
+	}
+
+	writer := csv.NewWriter(out)
+	if len(prior) == 0 {
+		if err := writeGiftCardIssueLog(writer, []string{"row", "recipient", "amount", "code", "id", "status", "error"}); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &GiftCardIssueSummary{}
+	for i, row := range rows {
+		rowNumber := i + 1
+		line := giftCardIssueLogLine{Row: rowNumber, Recipient: row.Recipient, Amount: row.Amount}
+
+		last, seen := prior[rowNumber]
+		if seen && last.Status == GiftCardIssueCreated {
+			summary.Resumed++
+			continue
+		}
+
+		// A Pending row may have been created just before the interruption.
+		if seen && last.Status == GiftCardIssuePending && last.Code != "" {
+			if giftCard, err := GetGiftCardByCode(last.Code, tenant, c7AppAuthEncoded, retryCount, rl); err == nil {
+				line.Code, line.ID, line.Status = giftCard.Code, giftCard.ID, GiftCardIssueCreated
+				if err := writeGiftCardIssueLog(writer, line.record()); err != nil {
+					return summary, err
+				}
+				summary.Resumed++
+				continue
+			}
+			line.Code = last.Code
+		}
+
+		if line.Code == "" {
+			code, err := uniqueGiftCardCode(opts.CodeLength, usedCodes)
+			if err != nil {
+				return summary, err
+			}
+			line.Code = code
+		}
+		usedCodes[line.Code] = true
+
+		line.Status = GiftCardIssuePending
+		if err := writeGiftCardIssueLog(writer, line.record()); err != nil {
+			return summary, err
+		}
+
+		notes := "Recipient: " + row.Recipient
+		if opts.Notes != "" {
+			notes += "\n" + opts.Notes
+		}
+
+		giftCard, err := CreateGiftCard(&GiftCardPost{
+			Title:         opts.Title,
+			Type:          opts.Type,
+			Code:          line.Code,
+			Status:        GiftCardStatusActive,
+			InitialAmount: row.Amount,
+			Notes:         notes,
+		}, tenant, c7AppAuthEncoded, retryCount, rl)
+		if err != nil {
+			line.Status, line.Err = GiftCardIssueFailed, err.Error()
+			summary.Failed++
+		} else {
+			line.ID, line.Status = giftCard.ID, GiftCardIssueCreated
+			summary.Created++
+		}
+
+		if err := writeGiftCardIssueLog(writer, line.record()); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// IssueGiftCardsFromCSVFile runs IssueGiftCards from an input CSV file,
+// logging to outputPath. Rerunning with the same paths resumes where an
+// interrupted run left off.
+func IssueGiftCardsFromCSVFile(inputPath string, outputPath string, opts GiftCardIssueOptions, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*GiftCardIssueSummary, error) {
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	rows, err := ReadGiftCardIssueCSV(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Close()
+
+	// Reading leaves the offset at the end, and O_APPEND writes there anyway.
+	return IssueGiftCards(rows, opts, output, output, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+func uniqueGiftCardCode(length int, used map[string]bool) (string, error) {
+	// With 31^length possible codes a collision is very unlikely, but a short
+	// length on a large program could loop forever without a cap.
+	for attempt := 0; attempt < 100; attempt++ {
+		code, err := GenerateGiftCardCode(length)
+		if err != nil {
+			return "", err
+		}
+		if !used[code] {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate an unused gift card code of length %d", length)
+}
+
+type giftCardIssueLogLine struct {
+	Row       int
+	Recipient string
+	Amount    Money
+	Code      string
+	ID        string
+	Status    string
+	Err       string
+}
+
+func (l giftCardIssueLogLine) record() []string {
+	return []string{strconv.Itoa(l.Row), l.Recipient, strconv.FormatInt(l.Amount.Cents, 10), l.Code, l.ID, l.Status, l.Err}
+}
+
+// writeGiftCardIssueLog writes and flushes a single line, so an interruption
+// loses at most the line in flight.
+func writeGiftCardIssueLog(writer *csv.Writer, record []string) error {
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// readGiftCardIssueLog returns the last line logged for each row.
+func readGiftCardIssueLog(r io.Reader) (map[int]giftCardIssueLogLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 7
+
+	lines := map[int]giftCardIssueLogLine{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while reading gift card issue log: %w", err)
+		}
+
+		row, err := strconv.Atoi(record[0])
+		if err != nil {
+			continue // header
+		}
+		amount, _ := strconv.ParseInt(record[2], 10, 64)
+		lines[row] = giftCardIssueLogLine{
+			Row:       row,
+			Recipient: record[1],
+			Amount:    Money{Cents: amount},
+			Code:      record[3],
+			ID:        record[4],
+			Status:    record[5],
+			Err:       record[6],
+		}
+	}
+	return lines, nil
+}
+
+// parseDollarAmount parses a non-negative dollar amount such as "49.99" or
+// "$50" without going through a float.
+func parseDollarAmount(s string) (Money, error) {
+	if strings.TrimSpace(s) == "" {
+		return Money{}, errors.New("amount is empty")
+	}
+	amount, err := ParseMoney(s, "")
+	if err != nil {
+		return Money{}, err
+	}
+	if amount.IsNegative() {
+		return Money{}, fmt.Errorf("invalid amount: %s", s)
+	}
+	return amount, nil
+}
@@ -0,0 +1,232 @@
+package c7api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCreateGiftCard_Validation(t *testing.T) {
+	var hits int32
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+
+	bad := []GiftCardPost{
+		{Title: "Card", Type: "Plastic", Status: GiftCardStatusActive, InitialAmount: Money{Cents: 5000}},
+		{Title: "Card", Type: GiftCardTypePhysical, Status: "Pending", InitialAmount: Money{Cents: 5000}},
+		{Title: "Card", Type: GiftCardTypePhysical, Status: GiftCardStatusActive, InitialAmount: Money{Cents: 0}},
+		{Type: GiftCardTypePhysical, Status: GiftCardStatusActive, InitialAmount: Money{Cents: 5000}},
+	}
+	for i, giftCard := range bad {
+		if _, err := CreateGiftCard(&giftCard, "t", "a", 0, nil); err == nil {
+			t.Errorf("case %d: expected a validation error", i)
+		}
+	}
+	if hits != 0 {
+		t.Errorf("made %d requests for invalid gift cards, want 0", hits)
+	}
+}
+
+func TestDecrementGiftCard(t *testing.T) {
+	var got GiftCardTransactionPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/gift-card-transaction" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.Write([]byte(`{"id":"tx1","giftCardId":"g1","amount":-1500}`))
+	}))
+
+	transaction, err := DecrementGiftCard("g1", Money{Cents: 1500}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Amount.Cents != -1500 || got.GiftCardId != "g1" {
+		t.Errorf("posted %+v, want a negative amount", got)
+	}
+	if transaction.Amount.Cents != -1500 {
+		t.Errorf("transaction = %+v", transaction)
+	}
+
+	if _, err := DecrementGiftCard("g1", Money{Cents: -5}, "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for a negative decrement")
+	}
+}
+
+func TestGetGiftCardByCode(t *testing.T) {
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total":2,"giftCards":[{"id":"g1","code":"ABCD1234X"},{"id":"g2","code":"ABCD1234"}]}`))
+	}))
+
+	giftCard, err := GetGiftCardByCode("abcd1234", "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if giftCard.ID != "g2" {
+		t.Errorf("got %s, want the exact code match g2", giftCard.ID)
+	}
+}
+
+func TestReadGiftCardIssueCSV(t *testing.T) {
+	rows, err := ReadGiftCardIssueCSV(strings.NewReader("Amount,Recipient\n50,Ann\n$49.99, Bob\n0.5,Cy\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []GiftCardIssueRow{{"Ann", Money{Cents: 5000}}, {"Bob", Money{Cents: 4999}}, {"Cy", Money{Cents: 50}}}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v", rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	if _, err := ReadGiftCardIssueCSV(strings.NewReader("recipient,amount\nAnn,1.234\n")); err == nil {
+		t.Error("expected an error for sub-cent amounts")
+	}
+	// A negative amount under a dollar sign must not come out positive.
+	if _, err := ReadGiftCardIssueCSV(strings.NewReader("recipient,amount\nAnn,$-0.50\n")); err == nil {
+		t.Error("expected an error for a negative amount")
+	}
+}
+
+func TestIssueGiftCards_Resume(t *testing.T) {
+	var creates int32
+	created := map[string]string{"PENDING00001": "g-pending"} // created before the interruption
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/gift-card":
+			q := r.URL.Query().Get("q")
+			cards := []GiftCard{}
+			for code, id := range created {
+				if q == "" || q == code {
+					cards = append(cards, GiftCard{ID: id, Code: code})
+				}
+			}
+			json.NewEncoder(w).Encode(GiftCards{GiftCards: cards, Total: len(cards)})
+		case r.Method == http.MethodPost && r.URL.Path == "/gift-card":
+			atomic.AddInt32(&creates, 1)
+			var post GiftCardPost
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &post)
+			if created[post.Code] != "" {
+				t.Errorf("code %s issued twice", post.Code)
+			}
+			created[post.Code] = "g-" + post.Code
+			json.NewEncoder(w).Encode(GiftCard{ID: "g-" + post.Code, Code: post.Code})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	rows := []GiftCardIssueRow{{"Ann", Money{Cents: 5000}}, {"Bob", Money{Cents: 2500}}, {"Cy", Money{Cents: 1000}}}
+	previous := "row,recipient,amount,code,id,status,error\n" +
+		"1,Ann,5000,DONE00000001,g-done,Pending,\n" +
+		"1,Ann,5000,DONE00000001,g-done,Created,\n" +
+		"2,Bob,2500,PENDING00001,,Pending,\n"
+
+	var out bytes.Buffer
+	summary, err := IssueGiftCards(rows, GiftCardIssueOptions{}, strings.NewReader(previous), &out, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Resumed != 2 || summary.Created != 1 || summary.Failed != 0 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if creates != 1 {
+		t.Errorf("made %d creates, want 1 (only the row never attempted)", creates)
+	}
+
+	// Appending to an existing log must not repeat the header.
+	if strings.Contains(out.String(), "recipient") {
+		t.Errorf("header written on resume:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "2,Bob,2500,PENDING00001,g-pending,Created,") {
+		t.Errorf("pending row not confirmed:\n%s", out.String())
+	}
+}
+
+// A log from a different input must not resume, or the wrong recipients
+// would be skipped or issued twice.
+func TestIssueGiftCards_ResumeChangedInput(t *testing.T) {
+	var requests int32
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+
+	previous := "row,recipient,amount,code,id,status,error\n" +
+		"1,Ann,5000,DONE00000001,g-done,Created,\n"
+
+	tests := []struct {
+		name string
+		rows []GiftCardIssueRow
+	}{
+		{"reordered", []GiftCardIssueRow{{"Bob", Money{Cents: 2500}}, {"Ann", Money{Cents: 5000}}}},
+		{"amount edited", []GiftCardIssueRow{{"Ann", Money{Cents: 7500}}}},
+		{"rows removed", []GiftCardIssueRow{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if _, err := IssueGiftCards(tc.rows, GiftCardIssueOptions{}, strings.NewReader(previous), &out, "t", "a", 0, nil); err == nil {
+				t.Error("expected an error for a changed input")
+			}
+			if out.Len() != 0 {
+				t.Errorf("wrote to the log:\n%s", out.String())
+			}
+		})
+	}
+	if requests != 0 {
+		t.Errorf("made %d requests, want 0", requests)
+	}
+}
+
+func TestBuildGiftCardLiabilityReport(t *testing.T) {
+	day := func(s string) time.Time {
+		v, _ := time.Parse("2006-01-02", s)
+		return v
+	}
+
+	giftCards := []GiftCard{
+		{ID: "a", Type: GiftCardTypePhysical, InitialAmount: Money{Cents: 5000}, Status: GiftCardStatusActive, CreatedAt: day("2026-01-05")},
+		{ID: "b", Type: GiftCardTypePhysical, InitialAmount: Money{Cents: 2500}, Status: GiftCardStatusActive, CreatedAt: day("2026-01-20")},
+		{ID: "c", Type: GiftCardTypeVirtual, InitialAmount: Money{Cents: 10000}, Status: GiftCardStatusActive, CreatedAt: day("2026-02-01")},
+		{ID: "d", Type: GiftCardTypeVirtual, InitialAmount: Money{Cents: 1000}, Status: GiftCardStatusCancelled, CreatedAt: day("2026-01-01"), UpdatedAt: day("2026-02-01")},
+		{ID: "e", Type: GiftCardTypeVirtual, InitialAmount: Money{Cents: 1000}, Status: GiftCardStatusActive, CreatedAt: day("2026-04-01")},
+		{ID: "f", Type: GiftCardTypePhysical, InitialAmount: Money{Cents: 1000}, Status: GiftCardStatusActive, CreatedAt: day("2026-01-01")},
+	}
+	transactions := []GiftCardTransaction{
+		{GiftCardID: "a", Amount: Money{Cents: -1999}, CreatedAt: day("2026-02-10")},
+		{GiftCardID: "a", Amount: Money{Cents: -3001}, CreatedAt: day("2026-04-10")}, // after asOf
+		{GiftCardID: "f", Amount: Money{Cents: -1000}, CreatedAt: day("2026-01-02")}, // fully redeemed
+	}
+
+	report := BuildGiftCardLiabilityReport(giftCards, transactions, day("2026-03-31"), nil)
+
+	if report.Total.Cents != 3001+2500+10000 || report.Total.Dollars() != 155.01 {
+		t.Errorf("total = %s", report.Total)
+	}
+	if len(report.Groups) != 2 {
+		t.Fatalf("groups = %+v", report.Groups)
+	}
+	if g := report.Groups[0]; g.Type != GiftCardTypePhysical || g.IssueMonth != "2026-01" || g.CardCount != 2 || g.Total.Cents != 5501 {
+		t.Errorf("physical group = %+v", g)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "Total,,3,155.01\n") {
+		t.Errorf("csv = %q", buf.String())
+	}
+}
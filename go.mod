@@ -1,6 +1,6 @@
 module github.com/Amnesiac9/c7api
 
-go 1.20
+go 1.21
 
 require github.com/joho/godotenv v1.5.1
 
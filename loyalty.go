@@ -0,0 +1,191 @@
+package c7api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Loyalty transactions require the Commerce7 Loyalty extension on the tenant.
+
+type LoyaltyTransactions struct {
+	LoyaltyTransactions []LoyaltyTransaction `json:"loyaltyTransactions"`
+	Total               int                  `json:"total"`
+}
+
+func (l LoyaltyTransactions) GetItems() []LoyaltyTransaction { return l.LoyaltyTransactions }
+func (l LoyaltyTransactions) GetTotal() int                  { return l.Total }
+
+// LoyaltyTransaction is a single change to a customer's points. Points are
+// positive for earns and negative for redemptions.
+type LoyaltyTransaction struct {
+	ID              string    `json:"id"`
+	CustomerID      string    `json:"customerId"`
+	TransactionType string    `json:"transactionType"` // See LoyaltyTransactionType constants
+	Points          int       `json:"points"`
+	OrderID         string    `json:"orderId"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// For posting a new loyalty transaction
+type LoyaltyTransactionPost struct {
+	CustomerID      string `json:"customerId"`
+	TransactionType string `json:"transactionType"`
+	Points          int    `json:"points"`
+	OrderID         string `json:"orderId,omitempty"`
+	Notes           string `json:"notes,omitempty"`
+}
+
+// GetLoyaltyTransactions returns every loyalty transaction matching queries,
+// walking all pages.
+func GetLoyaltyTransactions(queries map[string]string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]LoyaltyTransaction, error) {
+	transactions, err := GetAll[LoyaltyTransaction, LoyaltyTransactions](Endpoints.LoyaltyTransaction, queries, nil, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// GetCustomerLoyaltyTransactions returns a customer's full points history.
+func GetCustomerLoyaltyTransactions(customerId string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*[]LoyaltyTransaction, error) {
+	if customerId == "" {
+		return nil, errors.New("no customerId provided")
+	}
+	return GetLoyaltyTransactions(map[string]string{"customerId": customerId}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// CreateLoyaltyTransaction validates and posts a loyalty transaction.
+//
+// Earns must be positive and redemptions negative; adjustments may be either.
+func CreateLoyaltyTransaction(transaction *LoyaltyTransactionPost, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*LoyaltyTransaction, error) {
+	if transaction == nil {
+		return nil, errors.New("loyalty transaction cannot be nil")
+	}
+	if transaction.CustomerID == "" {
+		return nil, errors.New("no customerId provided")
+	}
+	if !IsValidLoyaltyTransactionType(transaction.TransactionType) {
+		return nil, fmt.Errorf("invalid loyalty transaction type: %s", transaction.TransactionType)
+	}
+
+	switch {
+	case transaction.Points == 0:
+		return nil, errors.New("loyalty transaction points cannot be zero")
+	case transaction.TransactionType == LoyaltyTransactionTypeEarn && transaction.Points < 0:
+		return nil, errors.New("earned points must be positive")
+	case transaction.TransactionType == LoyaltyTransactionTypeRedeem && transaction.Points > 0:
+		return nil, errors.New("redeemed points must be negative")
+	}
+
+	resp, err := Post(transaction, Endpoints.LoyaltyTransaction, tenant, c7AppAuthEncoded, retryCount, rl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create loyalty transaction: %w", err)
+	}
+
+	var created LoyaltyTransaction
+	if err := json.Unmarshal(*resp, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal loyalty transaction: %w", err)
+	}
+	return &created, nil
+}
+
+// AdjustLoyaltyPoints adds points (or removes them, if negative) with a note
+// explaining why.
+func AdjustLoyaltyPoints(customerId string, points int, notes string, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) (*LoyaltyTransaction, error) {
+	return CreateLoyaltyTransaction(&LoyaltyTransactionPost{
+		CustomerID:      customerId,
+		TransactionType: LoyaltyTransactionTypeAdjust,
+		Points:          points,
+		Notes:           notes,
+	}, tenant, c7AppAuthEncoded, retryCount, rl)
+}
+
+// LoyaltyGrant is one customer's share of a bulk points adjustment.
+type LoyaltyGrant struct {
+	CustomerID string
+	Points     int
+	Notes      string
+}
+
+// LoyaltyGrantResult reports what happened to one grant. Status is one of
+// BulkStatusApplied, BulkStatusSkipped (already granted) or BulkStatusFailed.
+type LoyaltyGrantResult struct {
+	CustomerID    string
+	Status        string
+	TransactionID string
+	Err           error
+}
+
+// BulkAdjustLoyaltyPoints applies grants as adjustments, at most once per
+// customer per key.
+//
+// The key (e.g. "2026-spring-promo") is written into each transaction's notes
+// as a "[grant:key]" marker. Before granting, the customer's history is
+// checked for that marker, so rerunning the same batch after a partial
+// failure only applies what is missing. Every grant is checked before any
+// request is made. Results are in the order of grants.
+func BulkAdjustLoyaltyPoints(key string, grants []LoyaltyGrant, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter) ([]LoyaltyGrantResult, error) {
+	if key == "" {
+		return nil, errors.New("an idempotency key is required for bulk loyalty adjustments")
+	}
+	marker := loyaltyGrantMarker(key)
+
+	seen := map[string]bool{}
+	for i, grant := range grants {
+		if grant.CustomerID == "" {
+			return nil, fmt.Errorf("grant %d has no customerId", i+1)
+		}
+		if grant.Points == 0 {
+			return nil, fmt.Errorf("grant for customer %s has zero points", grant.CustomerID)
+		}
+		if seen[grant.CustomerID] {
+			return nil, fmt.Errorf("customer %s appears more than once in the grant list", grant.CustomerID)
+		}
+		seen[grant.CustomerID] = true
+	}
+
+	results := make([]LoyaltyGrantResult, 0, len(grants))
+	for _, grant := range grants {
+		result := LoyaltyGrantResult{CustomerID: grant.CustomerID}
+
+		history, err := GetCustomerLoyaltyTransactions(grant.CustomerID, tenant, c7AppAuthEncoded, retryCount, rl)
+		if err != nil {
+			result.Status, result.Err = BulkStatusFailed, err
+			results = append(results, result)
+			continue
+		}
+
+		for _, transaction := range *history {
+			if strings.Contains(transaction.Notes, marker) {
+				result.Status, result.TransactionID = BulkStatusSkipped, transaction.ID
+				break
+			}
+		}
+		if result.Status == BulkStatusSkipped {
+			results = append(results, result)
+			continue
+		}
+
+		notes := marker
+		if grant.Notes != "" {
+			notes = grant.Notes + " " + marker
+		}
+
+		transaction, err := AdjustLoyaltyPoints(grant.CustomerID, grant.Points, notes, tenant, c7AppAuthEncoded, retryCount, rl)
+		if err != nil {
+			result.Status, result.Err = BulkStatusFailed, err
+		} else {
+			result.Status, result.TransactionID = BulkStatusApplied, transaction.ID
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func loyaltyGrantMarker(key string) string {
+	return "[grant:" + key + "]"
+}
@@ -0,0 +1,76 @@
+package c7api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestBulkAdjustLoyaltyPoints(t *testing.T) {
+	var gets int
+	var posted []LoyaltyTransactionPost
+	useTestEndpoints(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			if r.URL.Query().Get("customerId") == "already" {
+				w.Write([]byte(`{"total":1,"loyaltyTransactions":[{"id":"lt-old","notes":"Spring promo [grant:spring]"}]}`))
+				return
+			}
+			w.Write([]byte(`{"total":0,"loyaltyTransactions":[]}`))
+		case http.MethodPost:
+			var post LoyaltyTransactionPost
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &post)
+			if post.CustomerID == "fails" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message":"insufficient points"}`))
+				return
+			}
+			posted = append(posted, post)
+			w.Write([]byte(`{"id":"lt-new"}`))
+		}
+	}))
+
+	results, err := BulkAdjustLoyaltyPoints("spring", []LoyaltyGrant{
+		{CustomerID: "new", Points: 500, Notes: "Spring promo"},
+		{CustomerID: "already", Points: 500},
+		{CustomerID: "fails", Points: -900},
+	}, "t", "a", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{BulkStatusApplied, BulkStatusSkipped, BulkStatusFailed}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: status %s, want %s (err %v)", result.CustomerID, result.Status, want[i], result.Err)
+		}
+	}
+
+	if len(posted) != 1 {
+		t.Fatalf("posted %d transactions, want 1", len(posted))
+	}
+	if posted[0].Notes != "Spring promo [grant:spring]" || posted[0].TransactionType != LoyaltyTransactionTypeAdjust {
+		t.Errorf("posted %+v", posted[0])
+	}
+
+	if _, err := BulkAdjustLoyaltyPoints("spring", []LoyaltyGrant{{CustomerID: "a"}, {CustomerID: "a"}}, "t", "a", 0, nil); err == nil {
+		t.Error("expected an error for a duplicate customer")
+	}
+
+	// Invalid grants are refused before any history is read.
+	gets = 0
+	for _, grants := range [][]LoyaltyGrant{
+		{{CustomerID: "new", Points: 500}, {CustomerID: "zero", Points: 0}},
+		{{CustomerID: "new", Points: 500}, {Points: 500}},
+	} {
+		if _, err := BulkAdjustLoyaltyPoints("spring", grants, "t", "a", 0, nil); err == nil {
+			t.Errorf("expected an error for %+v", grants)
+		}
+	}
+	if gets != 0 {
+		t.Errorf("made %d history requests for invalid grants, want 0", gets)
+	}
+}
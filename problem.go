@@ -0,0 +1,112 @@
+package c7api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type for RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem document, for forwarding a Commerce7
+// failure to an API client in a standard format.
+//
+// Type, Title, Status, Detail and Instance are the members defined by the RFC.
+// Anything Commerce7-specific goes in Extensions, which are written alongside
+// them at the top level of the JSON object.
+type ProblemDetails struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+// InvalidParam is one entry of the "invalid-params" extension, in the shape
+// used by the example in RFC 7807 section 3.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Code   string `json:"code,omitempty"`
+}
+
+// MarshalJSON flattens Extensions into the top-level object. The standard
+// members always win over an extension with the same name.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		out[k] = v
+	}
+
+	out["type"] = p.Type
+	out["title"] = p.Title
+	out["status"] = p.Status
+	if p.Detail != "" {
+		out["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		out["instance"] = p.Instance
+	}
+
+	return json.Marshal(out)
+}
+
+// Problem converts the error to RFC 7807 problem details.
+//
+// The Commerce7 error type is carried in the "c7Type" extension rather than
+// the problem type, since it isn't a URI. Validation errors that decode as
+// FieldErrors become "invalid-params"; anything else is passed through raw
+// under "errors".
+//
+// A C7Error without a status (the body couldn't be read) is reported as 502,
+// since from the client's side it is a bad response from an upstream.
+func (e *C7Error) Problem() ProblemDetails {
+	status := e.StatusCode
+	if status == 0 {
+		status = http.StatusBadGateway
+	}
+
+	problem := ProblemDetails{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     e.Message,
+		Extensions: map[string]any{},
+	}
+
+	if e.Type != "" {
+		problem.Extensions["c7Type"] = e.Type
+	}
+
+	fieldErrors := e.FieldErrors()
+	if len(fieldErrors) > 0 {
+		params := make([]InvalidParam, 0, len(fieldErrors))
+		for _, fe := range fieldErrors {
+			params = append(params, InvalidParam{Name: fe.Field, Reason: fe.Message, Code: fe.Code})
+		}
+		problem.Extensions["invalid-params"] = params
+	}
+
+	if unparsed := e.UnparsedErrors(); len(unparsed) > 0 {
+		problem.Extensions["errors"] = unparsed
+	}
+
+	return problem
+}
+
+// WriteProblem writes the error to w as an application/problem+json response,
+// using the Commerce7 status as the response status.
+func (e *C7Error) WriteProblem(w http.ResponseWriter) error {
+	problem := e.Problem()
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,70 @@
+package c7api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestC7Error_Problem(t *testing.T) {
+	c7Error := &C7Error{
+		StatusCode: 422,
+		Type:       "validationError",
+		Message:    "One or more elements is missing or invalid",
+		Errors: []map[string]any{
+			{"field": "emails.0.email", "message": "must be a valid email"},
+			{"code": "something-new"},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	if err := c7Error.WriteProblem(rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rec.Code != 422 {
+		t.Errorf("status = %d, want 422", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ProblemContentType)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+
+	want := map[string]any{
+		"type":   "about:blank",
+		"title":  "Unprocessable Entity",
+		"status": float64(422),
+		"detail": "One or more elements is missing or invalid",
+		"c7Type": "validationError",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+
+	params, _ := got["invalid-params"].([]any)
+	if len(params) != 1 {
+		t.Fatalf("invalid-params = %v, want 1 entry", got["invalid-params"])
+	}
+	if p := params[0].(map[string]any); p["name"] != "emails.0.email" || p["reason"] != "must be a valid email" {
+		t.Errorf("invalid-params[0] = %v", p)
+	}
+
+	if raw, _ := got["errors"].([]any); len(raw) != 1 {
+		t.Errorf("errors = %v, want the unparsed entry passed through", got["errors"])
+	}
+}
+
+// An error without a status came from an unreadable upstream response.
+func TestC7Error_ProblemNoStatus(t *testing.T) {
+	problem := (&C7Error{}).Problem()
+	if problem.Status != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d", problem.Status, http.StatusBadGateway)
+	}
+}
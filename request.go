@@ -8,8 +8,39 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"time"
 )
 
+// ResponseMeta describes how a request went, beyond its body: the final
+// status and headers, how many attempts it took, and how long it took in
+// total including backoff. It is what Commerce7 support asks for when
+// chasing a failed call.
+//
+// StatusCode and Header come from the last response that could be read, so
+// they are zero if every attempt failed at the transport level.
+type ResponseMeta struct {
+	Method     string        // HTTP method of the request
+	URL        string        // Request URL, including any queries
+	StatusCode int           // Status of the final response
+	Header     http.Header   // Headers of the final response
+	RequestID  string        // Request id from the final response headers, if any
+	Attempts   int           // Number of requests sent
+	Latency    time.Duration // Wall time for the whole call, including backoff
+}
+
+// requestIDHeaders are checked in order for a request id to quote back to
+// Commerce7. Their API sits behind AWS, which sets the amzn headers.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Cf-Id"}
+
+func requestIDFromHeader(header http.Header) string {
+	for _, key := range requestIDHeaders {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // requestWithRetryAndRead is the shared implementation behind
 // RequestWithRetryAndRead (v1) and RequestWithRetryAndReadV2 (v2).
 //
@@ -17,9 +48,21 @@ import (
 // so a caller can add to them or override them. The v2 API uses this for the
 // two headers it requires on top of the v1 set.
 func requestWithRetryAndRead(ctx context.Context, method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter, extraHeaders map[string]string) (*[]byte, error) {
+	body, _, err := requestWithRetryAndReadMeta(ctx, method, url, queries, reqBody, tenant, c7AppAuthEncoded, retryCount, rl, extraHeaders)
+	return body, err
+}
+
+// requestWithRetryAndReadMeta is requestWithRetryAndRead, also returning what
+// is known about the exchange. The meta is never nil, even on error.
+func requestWithRetryAndReadMeta(ctx context.Context, method string, url string, queries map[string]string, reqBody *[]byte, tenant string, c7AppAuthEncoded string, retryCount int, rl genericRateLimiter, extraHeaders map[string]string) (*[]byte, *ResponseMeta, error) {
+	start := time.Now()
+	meta := &ResponseMeta{Method: method, URL: url}
+	// meta is a pointer, so this still lands after the return values are set.
+	defer func() { meta.Latency = time.Since(start) }()
+
 	//
 	if url == "" || tenant == "" || c7AppAuthEncoded == "" {
-		return nil, fmt.Errorf("error getting JSON from C7: nil or blank value in arguments")
+		return nil, meta, fmt.Errorf("error getting JSON from C7: nil or blank value in arguments")
 	}
 
 	if ctx == nil {
@@ -51,7 +94,7 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 		// The rate limiter and the sleeps below can hold us for a while, so
 		// check for cancellation before spending another attempt.
 		if err := ctx.Err(); err != nil {
-			return nil, err
+			return nil, meta, err
 		}
 
 		if rl != nil && !reflect.ValueOf(rl).IsNil() {
@@ -61,7 +104,7 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 		// A malformed method or url won't start working on a retry.
 		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(*reqBody))
 		if err != nil {
-			return nil, fmt.Errorf("error creating GET request for C7: %v", err)
+			return nil, meta, fmt.Errorf("error creating GET request for C7: %v", err)
 		}
 
 		if queries != nil {
@@ -71,6 +114,7 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 			}
 			req.URL.RawQuery = query.Encode()
 		}
+		meta.URL = req.URL.String()
 
 		req.Header.Set("tenant", tenant)
 		req.Header.Set("Content-Type", "application/json")
@@ -82,18 +126,19 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 
 		// Do returns a nil response alongside its error, so keep it out of
 		// `response` until we know the attempt produced something readable.
+		meta.Attempts++
 		resp, err := httpClient.Do(req)
 		if err != nil {
 			// A cancelled context surfaces here as an opaque *url.Error, so
 			// report ctx.Err() to keep errors.Is usable by the caller.
 			if ctxErr := ctx.Err(); ctxErr != nil {
-				return nil, ctxErr
+				return nil, meta, ctxErr
 			}
 			// Refused connection, DNS failure, timeout, dropped conn: the most
 			// transient failures there are, and the ones most worth retrying.
 			lastErr = fmt.Errorf("error making GET request to C7: %v", err)
 			if err := backoff(ctx, i, retryCount); err != nil {
-				return nil, err
+				return nil, meta, err
 			}
 			continue
 		}
@@ -102,22 +147,25 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 		resp.Body.Close()
 		if err != nil {
 			if ctxErr := ctx.Err(); ctxErr != nil {
-				return nil, ctxErr
+				return nil, meta, ctxErr
 			}
 			// A truncated body is transient in the same way.
 			lastErr = fmt.Errorf("error reading response body from C7: %v", err)
 			if err := backoff(ctx, i, retryCount); err != nil {
-				return nil, err
+				return nil, meta, err
 			}
 			continue
 		}
 
 		response = resp
 		lastErr = nil
+		meta.StatusCode = resp.StatusCode
+		meta.Header = resp.Header
+		meta.RequestID = requestIDFromHeader(resp.Header)
 
 		// 200-299 is success, return body and nil error
 		if ResponseIsOK(response.StatusCode) {
-			return &body, nil
+			return &body, meta, nil
 		}
 
 		// Fail fast on anything a retry can't change, so the caller sees the
@@ -127,14 +175,14 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 		}
 
 		if err := backoff(ctx, i, retryCount); err != nil {
-			return nil, err
+			return nil, meta, err
 		}
 	}
 
 	// Every attempt failed at the transport level, so there is no response
 	// body and no C7 error message to unmarshal.
 	if lastErr != nil {
-		return nil, lastErr
+		return nil, meta, lastErr
 	}
 
 	// Read the C7 Error if present
@@ -142,10 +190,11 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 	// If we have trouble reading it for some reason, handle that here.
 	c7Error := C7Error{}
 	err := c7Error.UnmarshalJSON(body)
+	c7Error.Response = meta
 	if err != nil {
 		c7Error.StatusCode = response.StatusCode
 		c7Error.Err = errors.New("error unmarshalling Commerce7 Error Message: " + err.Error() + "json: " + string(body))
-		return &body, &c7Error
+		return &body, meta, &c7Error
 	}
 
 	// Fall back to the HTTP status when the body didn't carry one,
@@ -154,8 +203,8 @@ func requestWithRetryAndRead(ctx context.Context, method string, url string, que
 		c7Error.StatusCode = response.StatusCode
 	}
 
-	// Add the raw json body to the err as well in case needed.
-	// TODO: Handle this better to allow slog nested json...
+	// Add the raw json body to the err as well in case needed. For structured
+	// logging, C7Error implements slog.LogValuer instead.
 	c7Error.Err = errors.New(string(body))
-	return &body, &c7Error
+	return &body, meta, &c7Error
 }
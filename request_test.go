@@ -1,8 +1,10 @@
 package c7api
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 )
 
@@ -86,3 +88,63 @@ func TestQueriesApplied(t *testing.T) {
 		t.Errorf("query q = %q, want %q", gotQuery, "1234")
 	}
 }
+
+// The meta variants report the final exchange, including on a 2xx that isn't
+// a 200 and after retries.
+func TestRequestWithRetryAndReadMeta(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-Amzn-Requestid", "req-"+strconv.Itoa(hits))
+		if hits == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	body, meta, err := RequestWithRetryAndReadMeta(http.MethodPost, srv.URL, nil, nil, "t", "a", 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(*body) != `{}` {
+		t.Errorf("body = %q", *body)
+	}
+	if meta.StatusCode != http.StatusAccepted {
+		t.Errorf("StatusCode = %d, want 202", meta.StatusCode)
+	}
+	if meta.RequestID != "req-2" {
+		t.Errorf("RequestID = %q, want the final response's id", meta.RequestID)
+	}
+	if meta.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2", meta.Attempts)
+	}
+	if meta.Latency < SLEEP_TIME {
+		t.Errorf("Latency = %v, want it to include the %v backoff", meta.Latency, SLEEP_TIME)
+	}
+}
+
+// A C7Error carries the same meta as the failed call.
+func TestRequestWithRetryAndReadMeta_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"missing"}`))
+	}))
+	defer srv.Close()
+
+	_, meta, err := RequestWithRetryAndReadMeta(http.MethodGet, srv.URL, nil, nil, "t", "a", 2, nil)
+
+	var c7err *C7Error
+	if !errors.As(err, &c7err) {
+		t.Fatalf("err = %T (%v), want *C7Error", err, err)
+	}
+	if c7err.Response != meta {
+		t.Error("C7Error.Response is not the returned meta")
+	}
+	if meta.RequestID != "abc" || meta.StatusCode != http.StatusNotFound {
+		t.Errorf("meta = %+v", meta)
+	}
+}
@@ -0,0 +1,352 @@
+package c7api
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UnknownFields keeps what a record's struct doesn't model, so a record can
+// be fetched, changed and sent back with Put without losing the parts this
+// package doesn't know about.
+//
+// Resource structs such as C7Order and CustomerFull carry an Unknown field of
+// this type, filled on decode by an UnmarshalJSON/MarshalJSON pair that goes
+// through a method-less alias of the struct:
+//
+//	func (o *C7Order) UnmarshalJSON(data []byte) error {
+//	    type alias C7Order
+//	    return decodeWithUnknown(data, (*alias)(o), &o.Unknown)
+//	}
+//
+// Only what decoding loses is kept, at every level: properties of nested
+// objects the struct doesn't model (on an address, a tender, an email), and
+// fractional amounts such as a line tax of 254.1, which Money rounds to 254.
+// On encode these are laid back over the struct's fields. An amount is sent
+// as it came only while its Money still holds the cents it decoded to. Array
+// elements are paired by id where they have one, and by position otherwise.
+// Nested structs with an Unknown field of their own keep what they need
+// themselves.
+//
+// Modeled fields always win: an unknown property never overwrites one that
+// was encoded from the struct, and a modeled field the struct leaves out
+// (emptied with omitempty) is not restored.
+type UnknownFields struct {
+	fields map[string]json.RawMessage // Unmodeled top-level properties, plus any Set
+	nested map[string]*keptValue      // What modeled top-level properties lost on decode
+}
+
+// keptValue is what decoding one JSON value into its Go type lost.
+type keptValue struct {
+	fields map[string]json.RawMessage // Unmodeled properties of an object
+	nested map[string]*keptValue      // Modeled properties of an object, or map entries
+	items  []keptItem                 // Every element of an array, if any lost something
+	amount json.RawMessage            // A Money amount that doesn't encode back as it came
+	cents  int64                      // What amount decoded to
+}
+
+type keptItem struct {
+	id   string
+	kept *keptValue // nil if the element lost nothing
+}
+
+// Get decodes the unknown property key into v, reporting whether it was
+// present.
+func (u UnknownFields) Get(key string, v any) (bool, error) {
+	raw, ok := u.fields[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// Set stores v as the unknown property key, to be sent on the next encode.
+func (u *UnknownFields) Set(key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if u.fields == nil {
+		u.fields = map[string]json.RawMessage{}
+	}
+	u.fields[key] = raw
+	return nil
+}
+
+// Keys returns the names of the unknown top-level properties, sorted.
+func (u UnknownFields) Keys() []string {
+	keys := make([]string, 0, len(u.fields))
+	for key := range u.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeWithUnknown unmarshals data into v, which must be a pointer to a
+// method-less alias of the resource struct, keeping in unknown every
+// top-level property v has no field for and whatever the modeled ones lose.
+func decodeWithUnknown(data []byte, v any, unknown *UnknownFields) error {
+	*unknown = UnknownFields{}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	kept := keepLost(reflect.TypeOf(v).Elem(), data)
+	if kept != nil {
+		unknown.fields, unknown.nested = kept.fields, kept.nested
+	}
+	return nil
+}
+
+// encodeWithUnknown marshals v, an alias of the resource struct, and lays
+// what unknown kept back over the result.
+func encodeWithUnknown(v any, unknown UnknownFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown.fields) == 0 && len(unknown.nested) == 0 {
+		return data, err
+	}
+	return restoreLost(&keptValue{fields: unknown.fields, nested: unknown.nested}, data), nil
+}
+
+var (
+	moneyType         = reflect.TypeOf(Money{})
+	jsonUnmarshalType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// keepLost returns what decoding raw into a value of type t loses, or nil if
+// nothing is.
+func keepLost(t reflect.Type, raw json.RawMessage) *keptValue {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == moneyType {
+		var m Money
+		if m.UnmarshalJSON(raw) != nil || string(bytes.TrimSpace(raw)) == strconv.FormatInt(m.Cents, 10) {
+			return nil
+		}
+		return &keptValue{amount: raw, cents: m.Cents}
+	}
+	// Types that decode themselves keep what they need themselves.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalType) {
+		return nil
+	}
+
+	kept := &keptValue{}
+	switch {
+	case t.Kind() == reflect.Struct && isJSONKind(raw, '{'):
+		var all map[string]json.RawMessage
+		if json.Unmarshal(raw, &all) != nil {
+			return nil
+		}
+		known := knownJSONFields(t)
+		for key, value := range all {
+			// encoding/json matches keys without regard to case, so do the same.
+			fieldType, ok := known[strings.ToLower(key)]
+			if !ok {
+				kept.keepField(key, value)
+			} else if lost := keepLost(fieldType, value); lost != nil {
+				kept.keepNested(key, lost)
+			}
+		}
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && isJSONKind(raw, '{'):
+		var all map[string]json.RawMessage
+		if json.Unmarshal(raw, &all) != nil {
+			return nil
+		}
+		for key, value := range all {
+			if lost := keepLost(t.Elem(), value); lost != nil {
+				kept.keepNested(key, lost)
+			}
+		}
+	case t.Kind() == reflect.Slice && isJSONKind(raw, '['):
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+		lostAny := false
+		kept.items = make([]keptItem, len(items))
+		for i, item := range items {
+			kept.items[i] = keptItem{id: jsonID(item), kept: keepLost(t.Elem(), item)}
+			lostAny = lostAny || kept.items[i].kept != nil
+		}
+		if !lostAny {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if len(kept.fields) == 0 && len(kept.nested) == 0 && len(kept.items) == 0 {
+		return nil
+	}
+	return kept
+}
+
+func (k *keptValue) keepField(key string, raw json.RawMessage) {
+	if k.fields == nil {
+		k.fields = map[string]json.RawMessage{}
+	}
+	k.fields[key] = raw
+}
+
+func (k *keptValue) keepNested(key string, lost *keptValue) {
+	if k.nested == nil {
+		k.nested = map[string]*keptValue{}
+	}
+	k.nested[key] = lost
+}
+
+// restoreLost lays kept back over encoded. Anything encoded that kept has
+// nothing for is left as it is.
+func restoreLost(kept *keptValue, encoded json.RawMessage) json.RawMessage {
+	switch {
+	case kept.amount != nil:
+		if cents, err := strconv.ParseInt(string(bytes.TrimSpace(encoded)), 10, 64); err == nil && cents == kept.cents {
+			return kept.amount
+		}
+	case isJSONKind(encoded, '{'):
+		return restoreObject(kept, encoded)
+	case isJSONKind(encoded, '[') && len(kept.items) > 0:
+		return restoreArray(kept.items, encoded)
+	}
+	return encoded
+}
+
+func restoreObject(kept *keptValue, encoded json.RawMessage) json.RawMessage {
+	var all map[string]json.RawMessage
+	if json.Unmarshal(encoded, &all) != nil {
+		return encoded
+	}
+
+	encodedKeys := make(map[string]bool, len(all))
+	for key, raw := range all {
+		encodedKeys[strings.ToLower(key)] = true
+		if lost := lookupKept(kept.nested, key); lost != nil {
+			all[key] = restoreLost(lost, raw)
+		}
+	}
+	for key, raw := range kept.fields {
+		if !encodedKeys[strings.ToLower(key)] {
+			all[key] = raw
+		}
+	}
+
+	out, err := json.Marshal(all)
+	if err != nil {
+		return encoded
+	}
+	return out
+}
+
+func restoreArray(items []keptItem, encoded json.RawMessage) json.RawMessage {
+	var encItems []json.RawMessage
+	if json.Unmarshal(encoded, &encItems) != nil {
+		return encoded
+	}
+
+	byID := map[string]*keptValue{}
+	for _, item := range items {
+		if item.id != "" {
+			byID[item.id] = item.kept
+		}
+	}
+
+	for i, raw := range encItems {
+		var lost *keptValue
+		if id := jsonID(raw); id != "" {
+			lost = byID[id]
+		} else if len(items) == len(encItems) && items[i].id == "" {
+			lost = items[i].kept
+		}
+		if lost != nil {
+			encItems[i] = restoreLost(lost, raw)
+		}
+	}
+
+	out, err := json.Marshal(encItems)
+	if err != nil {
+		return encoded
+	}
+	return out
+}
+
+// lookupKept finds key in nested, falling back to a match without regard to
+// case as encoding/json decodes.
+func lookupKept(nested map[string]*keptValue, key string) *keptValue {
+	if lost, ok := nested[key]; ok {
+		return lost
+	}
+	for k, lost := range nested {
+		if strings.EqualFold(k, key) {
+			return lost
+		}
+	}
+	return nil
+}
+
+// jsonID returns the "id" property of an object, if it has a string one.
+func jsonID(raw json.RawMessage) string {
+	if !isJSONKind(raw, '{') {
+		return ""
+	}
+	var withID struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(raw, &withID)
+	return withID.ID
+}
+
+func isJSONKind(raw json.RawMessage, open byte) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == open
+}
+
+var knownJSONFieldsCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+// knownJSONFields returns the lower-cased JSON names of every field t
+// decodes, including those promoted from embedded structs, with their types.
+func knownJSONFields(t reflect.Type) map[string]reflect.Type {
+	if cached, ok := knownJSONFieldsCache.Load(t); ok {
+		return cached.(map[string]reflect.Type)
+	}
+
+	known := map[string]reflect.Type{}
+	collectJSONFields(t, known)
+	knownJSONFieldsCache.Store(t, known)
+	return known
+}
+
+func collectJSONFields(t reflect.Type, known map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectJSONFields(embedded, known)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = field.Type
+	}
+}
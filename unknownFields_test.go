@@ -0,0 +1,269 @@
+package c7api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUnknownFields_OrderRoundTrip(t *testing.T) {
+	const payload = `{
+		"id": "o1",
+		"orderNumber": 1001,
+		"shippingInstructions": "Leave at the door",
+		"appData": {"my-app": {"synced": true}},
+		"metaData": {"gift-wrap": "yes"},
+		"items": [{"id": "i1", "sku": "CAB", "quantity": 2, "price": 4500, "tax": 254.1, "notes": [{"content": "engrave"}]}],
+		"total": 9000
+	}`
+
+	var order C7Order
+	if err := json.Unmarshal([]byte(payload), &order); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := strings.Join(order.Unknown.Keys(), ","); got != "appData,metaData" {
+		t.Errorf("order unknown = %s, want appData and metaData only", got)
+	}
+	if got := strings.Join(order.Items[0].Unknown.Keys(), ","); got != "notes" {
+		t.Errorf("item unknown = %s, want notes only", got)
+	}
+
+	var metaData map[string]string
+	if ok, err := order.Unknown.Get("metaData", &metaData); !ok || err != nil || metaData["gift-wrap"] != "yes" {
+		t.Errorf("Get(metaData) = %v, %v, %v", metaData, ok, err)
+	}
+
+	order.ShippingInstructions = "Ring the bell"
+	out, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if got["shippingInstructions"] != "Ring the bell" || got["total"] != float64(9000) {
+		t.Errorf("modeled fields = %v / %v", got["shippingInstructions"], got["total"])
+	}
+	if appData, _ := got["appData"].(map[string]any); appData["my-app"] == nil {
+		t.Errorf("appData lost: %s", out)
+	}
+	items, _ := got["items"].([]any)
+	if len(items) != 1 {
+		t.Fatalf("items = %v", got["items"])
+	}
+	if notes, _ := items[0].(map[string]any)["notes"].([]any); len(notes) != 1 {
+		t.Errorf("item notes lost: %s", out)
+	}
+	if !strings.Contains(string(out), `"tax":254.1`) {
+		t.Errorf("fractional line tax not sent back as it was: %s", out)
+	}
+}
+
+func TestUnknownFields_EmbeddedFieldsAreKnown(t *testing.T) {
+	const payload = `{"id": "c1", "firstName": "Ada", "city": "Napa", "stateCode": "CA", "lastName": "Lovelace", "preferredName": "Countess"}`
+
+	var customer CustomerFull
+	if err := json.Unmarshal([]byte(payload), &customer); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if customer.Firstname != "Ada" || customer.City != "Napa" {
+		t.Errorf("embedded fields not decoded: %+v", customer)
+	}
+	if got := strings.Join(customer.Unknown.Keys(), ","); got != "preferredName" {
+		t.Errorf("unknown = %s, want preferredName only", got)
+	}
+}
+
+func TestUnknownFields_ModeledFieldsWin(t *testing.T) {
+	giftCard := GiftCard{ID: "g1", Code: "ABC"}
+	if err := giftCard.Unknown.Set("code", "XYZ"); err != nil {
+		t.Fatal(err)
+	}
+	if err := giftCard.Unknown.Set("Extra", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := json.Marshal(&giftCard)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got map[string]any
+	json.Unmarshal(out, &got)
+	if got["code"] != "ABC" || got["Extra"] != float64(1) {
+		t.Errorf("encoded = %s", out)
+	}
+
+	// Decoding again must clear unknowns left over from earlier use.
+	if err := json.Unmarshal([]byte(`{"id":"g2"}`), &giftCard); err != nil {
+		t.Fatal(err)
+	}
+	if keys := giftCard.Unknown.Keys(); len(keys) != 0 {
+		t.Errorf("unknown = %v after decoding a fully modeled record", keys)
+	}
+}
+
+// Properties nested objects don't model must survive too, not only those at
+// the top level of the record.
+func TestUnknownFields_NestedRoundTrip(t *testing.T) {
+	const payload = `{
+		"id": "c1",
+		"firstName": "Ada",
+		"emails": [
+			{"id": "e1", "email": "ada@example.com", "status": "Subscribed", "isVerified": true},
+			{"id": "e2", "email": "old@example.com", "status": "Unsubscribed", "isVerified": false}
+		],
+		"loyalty": {"tier": "Gold", "points": 120, "expiresAt": "2027-01-01"},
+		"orderInformation": {"orderCount": 3, "lifetimeValue": 254.1, "grossProfit": 100.4, "currency": "USD"}
+	}`
+
+	var customer CustomerFull
+	if err := json.Unmarshal([]byte(payload), &customer); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	// Drop the second email and reorder nothing else; change one modeled
+	// nested field.
+	customer.Emails = customer.Emails[:1]
+	customer.Emails[0].Status = "Unsubscribed"
+	customer.OrderInformation.GrossProfit = Money{Cents: 200}
+
+	out, err := json.Marshal(customer)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got struct {
+		Emails           []map[string]any           `json:"emails"`
+		Loyalty          map[string]any             `json:"loyalty"`
+		OrderInformation map[string]json.RawMessage `json:"orderInformation"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	if len(got.Emails) != 1 || got.Emails[0]["status"] != "Unsubscribed" || got.Emails[0]["isVerified"] != true {
+		t.Errorf("emails = %v", got.Emails)
+	}
+	if got.Loyalty["expiresAt"] != "2027-01-01" || got.Loyalty["tier"] != "Gold" {
+		t.Errorf("loyalty = %v", got.Loyalty)
+	}
+	if string(got.OrderInformation["currency"]) != `"USD"` {
+		t.Errorf("orderInformation lost currency: %s", out)
+	}
+
+	// Unchanged fractional amounts go back exactly; changed ones as set.
+	if lv := string(got.OrderInformation["lifetimeValue"]); lv != "254.1" {
+		t.Errorf("lifetimeValue = %s, want 254.1 as sent", lv)
+	}
+	if gp := string(got.OrderInformation["grossProfit"]); gp != "200" {
+		t.Errorf("grossProfit = %s, want the new 200", gp)
+	}
+}
+
+// Clearing a modeled field must not bring the original value back, whether
+// it encodes as null or is left out by omitempty.
+func TestUnknownFields_ClearedFieldStaysCleared(t *testing.T) {
+	var order C7Order
+	payload := `{"id":"o1","salesAttributeCode":"WINE","carryOut":{"inventoryLocationId":"l1","vatNumber":"X1"}}`
+	if err := json.Unmarshal([]byte(payload), &order); err != nil {
+		t.Fatal(err)
+	}
+	order.SalesAttributeCode = ""
+	order.CarryOut = nil
+
+	out, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	json.Unmarshal(out, &got)
+	if _, ok := got["salesAttributeCode"]; ok {
+		t.Errorf("salesAttributeCode = %v, want it left out", got["salesAttributeCode"])
+	}
+	if got["carryOut"] != nil {
+		t.Errorf("carryOut = %v, want null", got["carryOut"])
+	}
+}
+
+// Only a Money that still holds the cents it decoded to is sent back as it
+// came; a deliberate change, or any other kind of number, is sent as set.
+func TestUnknownFields_ChangedAmountIsSent(t *testing.T) {
+	var item OrderItem
+	if err := json.Unmarshal([]byte(`{"id":"i1","price":4500,"tax":254.1}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	var variant C7ProductVariant
+	if err := json.Unmarshal([]byte(`{"id":"v1","weight":1.4}`), &variant); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"unchanged tax", item, `"tax":254.1`},
+		{"changed tax", func() OrderItem { i := item; i.Tax = Money{Cents: 300}; return i }(), `"tax":300`},
+		{"weight rounded down", func() C7ProductVariant { v := variant; w := 1.0; v.Weight = &w; return v }(), `"weight":1`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(out), tc.want) {
+				t.Errorf("encoded %s, want %s", out, tc.want)
+			}
+		})
+	}
+}
+
+// A record keeps nothing it can rebuild from its fields, and nested structs
+// with their own Unknown aren't kept again by the record holding them.
+func TestUnknownFields_KeepsOnlyWhatIsLost(t *testing.T) {
+	var order C7Order
+	payload := `{"id":"o1","orderNumber":1001,"total":9000,"items":[{"id":"i1","sku":"CAB","tax":254.1,"notes":[]}]}`
+	if err := json.Unmarshal([]byte(payload), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Unknown.fields != nil || order.Unknown.nested != nil {
+		t.Errorf("order kept %+v", order.Unknown)
+	}
+	if keys := order.Items[0].Unknown.Keys(); len(keys) != 1 || keys[0] != "notes" {
+		t.Errorf("item unknown = %v, want notes", keys)
+	}
+}
+
+// Writing a product or club back through ToPost must not drop what Unknown
+// kept.
+func TestUnknownFields_ToPostCarriesUnknown(t *testing.T) {
+	var product C7Product
+	payload := `{"id":"p1","title":"Cab","appData":{"my-app":1},"seo":{"title":"Cab","keywords":"red"},
+		"variants":[{"id":"v1","sku":"CAB","price":4500,"barcode":"0123"}]}`
+	if err := json.Unmarshal([]byte(payload), &product); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(product.ToPost())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"appData":{"my-app":1}`, `"keywords":"red"`, `"barcode":"0123"`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("product post %s lost %s", out, want)
+		}
+	}
+
+	var club C7Club
+	if err := json.Unmarshal([]byte(`{"id":"c1","title":"Reserve","clubLevel":"Gold"}`), &club); err != nil {
+		t.Fatal(err)
+	}
+	out, err = json.Marshal(club.ToPost())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"clubLevel":"Gold"`) {
+		t.Errorf("club post %s lost clubLevel", out)
+	}
+}